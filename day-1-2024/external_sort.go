@@ -0,0 +1,391 @@
+package main
+
+import (
+	"aoc-2024/datastructures"
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Ascending sequence of integers, ok is false once the stream is exhausted
+type SortedStream interface {
+	Next() (int, bool, error)
+}
+
+type sliceStream struct {
+	values []int
+	pos    int
+}
+
+func (s *sliceStream) Next() (int, bool, error) {
+	if s.pos >= len(s.values) {
+		return 0, false, nil
+	}
+	s.pos++
+	return s.values[s.pos-1], true, nil
+}
+
+// Most run files merged, and so kept open, at the same time by a single sorter
+const MAX_OPEN_RUNS = 64
+
+// Sorts more integers than fit in memory: values are buffered in chunks of chunkSize,
+// every full chunk is sorted and spilled to a temporary run file, and Sorted merges the runs.
+type ExternalSorter struct {
+	chunkSize   int
+	maxOpenRuns int
+	dir         string
+	buf         []int
+	runs        []string
+	files       []*os.File
+	created     int
+}
+
+func NewExternalSorter(chunkSize int) (*ExternalSorter, error) {
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("Chunk size must be positive, found %d", chunkSize)
+	}
+
+	dir, err := os.MkdirTemp("", "aoc-external-sort-*")
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalSorter{chunkSize: chunkSize, maxOpenRuns: MAX_OPEN_RUNS, dir: dir}, nil
+}
+
+func (s *ExternalSorter) Add(v int) error {
+	s.buf = append(s.buf, v)
+	if len(s.buf) >= s.chunkSize {
+		return s.spill()
+	}
+	return nil
+}
+
+func (s *ExternalSorter) spill() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	slices.Sort(s.buf)
+
+	path, err := s.writeRun(&sliceStream{values: s.buf})
+	if err != nil {
+		return err
+	}
+
+	s.runs = append(s.runs, path)
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// Writes the whole stream to a new run file and returns its path
+func (s *ExternalSorter) writeRun(stream SortedStream) (string, error) {
+	path := filepath.Join(s.dir, fmt.Sprintf("run-%d", s.created))
+	s.created++
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	writer := bufio.NewWriter(file)
+	encoded := []byte{}
+	for {
+		v, ok, err := stream.Next()
+		if err != nil {
+			file.Close()
+			return "", err
+		}
+		if !ok {
+			break
+		}
+
+		encoded = binary.AppendVarint(encoded[:0], int64(v))
+		if _, err := writer.Write(encoded); err != nil {
+			file.Close()
+			return "", err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// Number of runs spilled to disk so far
+func (s *ExternalSorter) Runs() int {
+	return len(s.runs)
+}
+
+// Opens the given runs and returns a k-way merge of them, the files are tracked in s.files
+func (s *ExternalSorter) openMerge(paths []string) (*runMerger, error) {
+	m := &runMerger{}
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		s.files = append(s.files, file)
+
+		r := &runReader{bufio.NewReader(file)}
+		if err := m.push(r); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Closes every open run file and deletes the given runs
+func (s *ExternalSorter) closeRuns(paths []string) error {
+	errs := []error{}
+	for _, file := range s.files {
+		errs = append(errs, file.Close())
+	}
+	s.files = s.files[:0]
+	for _, path := range paths {
+		errs = append(errs, os.Remove(path))
+	}
+	return errors.Join(errs...)
+}
+
+// Merges groups of at most maxOpenRuns runs into a single new run, until few enough are left
+// to be merged at once
+func (s *ExternalSorter) mergePass() error {
+	merged := []string{}
+	for start := 0; start < len(s.runs); start += s.maxOpenRuns {
+		group := s.runs[start:min(start+s.maxOpenRuns, len(s.runs))]
+
+		m, err := s.openMerge(group)
+		if err != nil {
+			return errors.Join(err, s.closeRuns(nil))
+		}
+		path, err := s.writeRun(m)
+		if err := errors.Join(err, s.closeRuns(group)); err != nil {
+			return err
+		}
+		merged = append(merged, path)
+	}
+	s.runs = merged
+	return nil
+}
+
+// Spills what is left in memory and returns a k-way merge of all the runs, after as many
+// merge passes as needed to have at most maxOpenRuns files open. No more values can be added afterwards.
+func (s *ExternalSorter) Sorted() (SortedStream, error) {
+	if err := s.spill(); err != nil {
+		return nil, err
+	}
+
+	for len(s.runs) > s.maxOpenRuns {
+		if err := s.mergePass(); err != nil {
+			return nil, err
+		}
+	}
+	return s.openMerge(s.runs)
+}
+
+// Removes the run files
+func (s *ExternalSorter) Close() error {
+	errs := []error{}
+	for _, file := range s.files {
+		errs = append(errs, file.Close())
+	}
+	errs = append(errs, os.RemoveAll(s.dir))
+	return errors.Join(errs...)
+}
+
+type runReader struct {
+	reader *bufio.Reader
+}
+
+func (r *runReader) Next() (int, bool, error) {
+	v, err := binary.ReadVarint(r.reader)
+	if err == io.EOF {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return int(v), true, nil
+}
+
+type runHead struct {
+	value int
+	run   *runReader
+}
+
+func (a runHead) CompareWith(e datastructures.ElementWithPriority) int {
+	b := e.(runHead)
+
+	if a.value < b.value {
+		return -1
+	}
+
+	if a.value > b.value {
+		return 1
+	}
+
+	return 0
+}
+
+// Keeps the current head of every run in a min heap
+type runMerger struct {
+	heads datastructures.PriorityQueue
+}
+
+func (m *runMerger) push(r *runReader) error {
+	v, ok, err := r.Next()
+	if err != nil {
+		return err
+	}
+	if ok {
+		m.heads.Insert(runHead{v, r})
+	}
+	return nil
+}
+
+func (m *runMerger) Next() (int, bool, error) {
+	if len(m.heads) == 0 {
+		return 0, false, nil
+	}
+
+	head := (*m.heads.Remove()).(runHead)
+	if err := m.push(head.run); err != nil {
+		return 0, false, err
+	}
+	return head.value, true, nil
+}
+
+// Sum of the distances between the i-th values of two sorted streams of the same length
+func distanceStreams(a SortedStream, b SortedStream) (int, error) {
+	res := 0
+	for {
+		valA, okA, err := a.Next()
+		if err != nil {
+			return 0, err
+		}
+		valB, okB, err := b.Next()
+		if err != nil {
+			return 0, err
+		}
+
+		if okA != okB {
+			return 0, errors.New("Columns have different lengths")
+		}
+		if !okA {
+			return res, nil
+		}
+		res += abs(valA - valB)
+	}
+}
+
+type peekStream struct {
+	stream SortedStream
+	value  int
+	ok     bool
+}
+
+func newPeekStream(s SortedStream) (*peekStream, error) {
+	p := &peekStream{stream: s}
+	return p, p.advance()
+}
+
+func (p *peekStream) advance() error {
+	var err error
+	p.value, p.ok, err = p.stream.Next()
+	return err
+}
+
+// Consumes every copy of the current value, returns how many there were
+func (p *peekStream) countRun() (int, error) {
+	val := p.value
+	count := 0
+	for p.ok && p.value == val {
+		count++
+		if err := p.advance(); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// Similarity score of two sorted streams, walking them once in lockstep
+func similarityStreams(a SortedStream, b SortedStream) (int, error) {
+	peekA, err := newPeekStream(a)
+	if err != nil {
+		return 0, err
+	}
+	peekB, err := newPeekStream(b)
+	if err != nil {
+		return 0, err
+	}
+
+	res := 0
+	for peekA.ok && peekB.ok {
+		if peekA.value < peekB.value {
+			err = peekA.advance()
+		} else if peekA.value > peekB.value {
+			err = peekB.advance()
+		} else {
+			val := peekA.value
+			countA, errA := peekA.countRun()
+			countB, errB := peekB.countRun()
+			res += val * countA * countB
+			err = errors.Join(errA, errB)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+	return res, nil
+}
+
+// Distance (part 1) or similarity (part 2) between two columns of r, sorting them on disk
+// so that neither column has to fit in memory
+func ExternalCompare(r io.Reader, a int, b int, chunkSize int, metric Metric) (int, error) {
+	if metric != DISTANCE && metric != SIMILARITY {
+		return 0, fmt.Errorf("Unknown metric %d", metric)
+	}
+
+	sorterA, err := NewExternalSorter(chunkSize)
+	if err != nil {
+		return 0, err
+	}
+	defer sorterA.Close()
+
+	sorterB, err := NewExternalSorter(chunkSize)
+	if err != nil {
+		return 0, err
+	}
+	defer sorterB.Close()
+
+	err = scanRows(r, func(row []int) error {
+		if a < 0 || a >= len(row) || b < 0 || b >= len(row) {
+			return fmt.Errorf("Columns %d and %d do not exist, there are %d columns", a, b, len(row))
+		}
+		return errors.Join(sorterA.Add(row[a]), sorterB.Add(row[b]))
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	streamA, err := sorterA.Sorted()
+	if err != nil {
+		return 0, err
+	}
+	streamB, err := sorterB.Sorted()
+	if err != nil {
+		return 0, err
+	}
+
+	if metric == DISTANCE {
+		return distanceStreams(streamA, streamB)
+	}
+	return similarityStreams(streamA, streamB)
+}
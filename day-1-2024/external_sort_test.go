@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func randomInput(rows int, maxValue int) string {
+	rng := rand.New(rand.NewSource(1))
+	builder := strings.Builder{}
+	for idx := 0; idx < rows; idx++ {
+		fmt.Fprintf(&builder, "%d   %d\n", rng.Intn(maxValue), rng.Intn(maxValue))
+	}
+	return builder.String()
+}
+
+func TestExternalCompareMatchesInMemory(t *testing.T) {
+	// chunks of 3 values give more runs than MAX_OPEN_RUNS, so Sorted needs several merge passes
+	input := randomInput(1000, 50)
+
+	columns, err := ReadColumns(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Expected input to parse, got %v instead", err)
+	}
+
+	distance, err := ExternalCompare(strings.NewReader(input), 0, 1, 3, DISTANCE)
+	if err != nil || distance != columns.Distance(0, 1) {
+		t.Fatalf("Expected external distance to be %d, it was %d (%v) instead", columns.Distance(0, 1), distance, err)
+	}
+
+	similarity, err := ExternalCompare(strings.NewReader(input), 0, 1, 3, SIMILARITY)
+	expected := columns.Similarity(0, 1, FREQUENCY_MAP)
+	if err != nil || similarity != expected {
+		t.Fatalf("Expected external similarity to be %d, it was %d (%v) instead", expected, similarity, err)
+	}
+
+	if _, err := ExternalCompare(strings.NewReader(input), 0, 1, 3, Metric(2)); err == nil {
+		t.Fatalf("Expected an unknown metric to be rejected")
+	}
+}
+
+func TestExternalSorterMergePasses(t *testing.T) {
+	sorter, err := NewExternalSorter(2)
+	if err != nil {
+		t.Fatalf("Expected sorter to be created, got %v instead", err)
+	}
+	defer sorter.Close()
+	sorter.maxOpenRuns = 3
+
+	values := []int{}
+	for idx := 0; idx < 101; idx++ {
+		v := (idx * 37) % 101
+		values = append(values, v)
+		if err := sorter.Add(v); err != nil {
+			t.Fatalf("Expected %d to be added, got %v instead", v, err)
+		}
+	}
+
+	stream, err := sorter.Sorted()
+	if err != nil {
+		t.Fatalf("Expected runs to be merged, got %v instead", err)
+	}
+	if len(sorter.files) > 3 {
+		t.Fatalf("Expected at most 3 open runs, found %d instead", len(sorter.files))
+	}
+
+	sorted := []int{}
+	for {
+		v, ok, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Expected stream to be read, got %v instead", err)
+		}
+		if !ok {
+			break
+		}
+		sorted = append(sorted, v)
+	}
+
+	slices.Sort(values)
+	if !slices.Equal(sorted, values) {
+		t.Fatalf("Expected merged runs to be %v, they were %v instead", values, sorted)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type SimilarityMode int
+
+const (
+	FREQUENCY_MAP SimilarityMode = iota
+	SORTED_MERGE
+)
+
+// What to compute between two columns
+type Metric int
+
+const (
+	DISTANCE Metric = iota
+	SIMILARITY
+)
+
+// Location lists stored column by column, columns[c][row]
+type Columns [][]int
+
+// Reads whitespace separated integer columns, one row per line. The first non empty line
+// decides how many columns there are, every other line must have the same number.
+func ReadColumns(r io.Reader) (Columns, error) {
+	columns := Columns{}
+	err := scanRows(r, func(row []int) error {
+		if len(columns) == 0 {
+			columns = make(Columns, len(row))
+		}
+		for idx, num := range row {
+			columns[idx] = append(columns[idx], num)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// Calls visit with every non empty line parsed as integers, without keeping anything in memory.
+// The row slice is reused between calls.
+func scanRows(r io.Reader, visit func(row []int) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	numColumns := -1
+	row := []int{}
+	lineIdx := 0
+	for scanner.Scan() {
+		lineIdx++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if numColumns < 0 {
+			numColumns = len(fields)
+		}
+		if len(fields) != numColumns {
+			return fmt.Errorf("Line %d has %d columns, expected %d", lineIdx, len(fields), numColumns)
+		}
+
+		row = row[:0]
+		for idx, field := range fields {
+			num, err := strconv.Atoi(field)
+			if err != nil {
+				return fmt.Errorf("Line %d, column %d: %w", lineIdx, idx+1, err)
+			}
+			row = append(row, num)
+		}
+
+		if err := visit(row); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (c Columns) checkColumn(col int) {
+	if col < 0 || col >= len(c) {
+		panic(fmt.Sprintf("Column %d does not exist, there are %d columns", col, len(c)))
+	}
+}
+
+func (c Columns) sorted(col int) []int {
+	c.checkColumn(col)
+	res := slices.Clone(c[col])
+	slices.Sort(res)
+	return res
+}
+
+// Sum of the distances between the i-th smallest values of the two columns
+func (c Columns) Distance(a int, b int) int {
+	listA := c.sorted(a)
+	listB := c.sorted(b)
+
+	res := 0
+	for idx := 0; idx < len(listA); idx++ {
+		res += abs(listA[idx] - listB[idx])
+	}
+	return res
+}
+
+// Sum over the values of column a of the value times how many times it appears in column b
+func (c Columns) Similarity(a int, b int, mode SimilarityMode) int {
+	switch mode {
+	case FREQUENCY_MAP:
+		return c.similarityFrequency(a, b)
+	case SORTED_MERGE:
+		return similarityMerge(c.sorted(a), c.sorted(b))
+	default:
+		panic(fmt.Sprintf("Unknown similarity mode %d", mode))
+	}
+}
+
+func (c Columns) similarityFrequency(a int, b int) int {
+	c.checkColumn(a)
+	c.checkColumn(b)
+
+	freq := map[int]int{}
+	for _, elem := range c[b] {
+		freq[elem] += 1
+	}
+
+	res := 0
+	for _, elem := range c[a] {
+		res += elem * freq[elem]
+	}
+	return res
+}
+
+// Both lists must be sorted, walks them once in lockstep
+func similarityMerge(listA []int, listB []int) int {
+	// slices never fail to stream
+	res, _ := similarityStreams(&sliceStream{values: listA}, &sliceStream{values: listB})
+	return res
+}
@@ -1,105 +1,73 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"os"
-	"slices"
-	"strconv"
-	"strings"
 
 	"golang.org/x/exp/constraints"
 )
 
-func readFileAsString(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-
-	if err != nil {
-		return nil, err
-	}
-
-	lines := []string{}
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		lines = append(lines, line)
-	}
-
-	return lines, nil
-}
-
 func abs[T constraints.Signed | constraints.Float](v T) T {
 	return max(-v, v)
 }
 
-func makeLists(lines []string) ([]int, []int) {
-	list1 := []int{}
-	list2 := []int{}
+const IN_FILE_PATH = "./input.txt"
 
-	for _, line := range lines {
-		elements := strings.Split(line, "   ")
-		num1, _ := strconv.Atoi(elements[0])
-		num2, _ := strconv.Atoi(elements[1])
+func main() {
+	colA := flag.Int("a", 0, "First column to compare")
+	colB := flag.Int("b", 1, "Second column to compare")
+	similarity := flag.String("similarity", "map", "How to compute the similarity score: map or merge")
+	externalChunk := flag.Int("external-chunk", 0, "Sort on disk in runs of this many values instead of in memory, 0 to disable")
+	flag.Parse()
 
-		list1 = append(list1, num1)
-		list2 = append(list2, num2)
+	if flag.NArg() != 1 {
+		panic("Exactly one arg is expected")
 	}
+	arg := flag.Arg(0)
 
-	return list1, list2
-}
-
-func solvePart1(list1 []int, list2 []int) int {
-	slices.Sort(list1)
-	slices.Sort(list2)
-
-	res := 0
+	if arg != "1" && arg != "2" {
+		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
+	}
 
-	for i := 0; i < len(list1); i++ {
-		res += abs(list1[i] - list2[i])
+	file, err := os.Open(IN_FILE_PATH)
+	if err != nil {
+		println(err.Error())
+		panic("Error reading from " + IN_FILE_PATH)
 	}
-	return res
-}
+	defer file.Close()
 
-func solvePart2(list1 []int, list2 []int) int {
-	res := 0
+	mode := FREQUENCY_MAP
+	if *similarity == "merge" {
+		mode = SORTED_MERGE
+	} else if *similarity != "map" {
+		panic("Similarity can only be map or merge")
+	}
 
-	for _, elem := range list1 {
-		count := 0
-		for _, elem2 := range list2 {
-			if elem == elem2 {
-				count += 1
-			}
+	// on disk the lists are only ever sorted, so the similarity is always a merge
+	if *externalChunk > 0 {
+		metric := DISTANCE
+		if arg == "2" {
+			metric = SIMILARITY
 		}
 
-		res += count * elem
+		res, err := ExternalCompare(file, *colA, *colB, *externalChunk, metric)
+		if err != nil {
+			println(err.Error())
+			panic("Error sorting " + IN_FILE_PATH + " on disk")
+		}
+		println(res)
+		return
 	}
 
-	return res
-}
-
-const IN_FILE_PATH = "./input.txt"
-
-func main() {
-	data, err := readFileAsString(IN_FILE_PATH)
-
+	columns, err := ReadColumns(file)
 	if err != nil {
-		println(err)
-		panic("Error reading from " + IN_FILE_PATH)
-	}
-
-	if len(os.Args) != 2 {
-		panic("Exactly one arg is expected")
-	}
-	arg := os.Args[1]
-
-	if arg != "1" && arg != "2" {
-		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
+		println(err.Error())
+		panic("Error parsing " + IN_FILE_PATH)
 	}
-	list1, list2 := makeLists(data)
 
 	if arg == "1" {
-		println(solvePart1(list1, list2))
+		println(columns.Distance(*colA, *colB))
 	} else {
-		println(solvePart2(list1, list2))
+		println(columns.Similarity(*colA, *colB, mode))
 	}
 }
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"aoc-2024/datastructures"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type Reason int
+
+const (
+	NO_VIOLATION Reason = iota
+	DIRECTION_CHANGE
+	STEP_ZERO
+	STEP_TOO_SMALL
+	STEP_TOO_BIG
+)
+
+func (r Reason) String() string {
+	return []string{"", "direction change", "step zero", "step too small", "step too big"}[r]
+}
+
+func (r Reason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+type Diagnostic struct {
+	Line   int   `json:"line"`
+	Report []int `json:"report"`
+	// Whether the fix takes at most the allowed number of removals
+	Safe bool `json:"safe"`
+	// Index of the second level of the first bad pair, -1 if the report is safe as it is
+	ViolationIndex int    `json:"violation_index"`
+	Reason         Reason `json:"reason,omitempty"`
+	// Fewest levels to remove to make the report safe, however many that is. nil when that would leave
+	// fewer than 2 levels of a longer report, at that point nothing is left to check
+	Fix []int `json:"fix"`
+	// Direction of the report once fixed, nil when there is no fix
+	FixAscending *bool `json:"fix_ascending,omitempty"`
+}
+
+// First pair of adjacent levels that breaks the rules, the direction is set by the first two different levels
+func firstViolation(report []int, bounds StepBounds) (int, Reason) {
+	if len(report) < 2 {
+		return -1, NO_VIOLATION
+	}
+
+	ascending := true
+	for idx := 1; idx < len(report); idx++ {
+		if report[idx] != report[idx-1] {
+			ascending = report[idx] > report[idx-1]
+			break
+		}
+	}
+
+	for idx := 1; idx < len(report); idx++ {
+		diff := report[idx] - report[idx-1]
+		step := datastructures.Abs(diff)
+
+		if diff == 0 && bounds.Min > 0 {
+			return idx, STEP_ZERO
+		}
+		if diff != 0 && (diff > 0) != ascending {
+			return idx, DIRECTION_CHANGE
+		}
+		if step < bounds.Min {
+			return idx, STEP_TOO_SMALL
+		}
+		if step > bounds.Max {
+			return idx, STEP_TOO_BIG
+		}
+	}
+	return -1, NO_VIOLATION
+}
+
+func Diagnose(lines [][]int, k int, bounds StepBounds) []Diagnostic {
+	res := []Diagnostic{}
+	for idx, row := range lines {
+		violation, reason := firstViolation(row, bounds)
+		// with no limit on removals the minimal fix is found even when it needs more than k
+		verdict := CheckReport(row, len(row), bounds)
+
+		d := Diagnostic{
+			Line:           idx + 1,
+			Report:         row,
+			ViolationIndex: violation,
+			Reason:         reason,
+		}
+		// removing levels one by one always ends in a safe report, so the verdict is always safe here
+		d.Safe = len(verdict.Removed) <= k
+		if len(row) < 2 || len(row)-len(verdict.Removed) >= 2 {
+			d.Fix = verdict.Removed
+			d.FixAscending = &verdict.Ascending
+		}
+		res = append(res, d)
+	}
+	return res
+}
+
+func WriteDiagnosticsJSON(w io.Writer, diagnostics []Diagnostic) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
+
+func WriteDiagnosticsTable(w io.Writer, diagnostics []Diagnostic) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tREPORT\tVERDICT\tINDEX\tREASON\tFIX")
+
+	for _, d := range diagnostics {
+		verdict := "safe"
+		index := "-"
+		reason := "-"
+		fix := "-"
+
+		if d.ViolationIndex >= 0 {
+			index = fmt.Sprint(d.ViolationIndex)
+			reason = d.Reason.String()
+		}
+
+		if !d.Safe {
+			verdict = "unsafe"
+		} else if d.Fix == nil || len(d.Fix) > 0 {
+			verdict = "fixable"
+		}
+
+		if d.Fix == nil {
+			fix = "none"
+		} else if len(d.Fix) > 0 {
+			direction := "descending"
+			if *d.FixAscending {
+				direction = "ascending"
+			}
+			fix = fmt.Sprintf("remove %s (%s)", joinInts(d.Fix), direction)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", d.Line, joinInts(d.Report), verdict, index, reason, fix)
+	}
+	return tw.Flush()
+}
+
+func joinInts(nums []int) string {
+	parts := []string{}
+	for _, num := range nums {
+		parts = append(parts, fmt.Sprint(num))
+	}
+	return strings.Join(parts, " ")
+}
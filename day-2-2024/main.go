@@ -1,8 +1,8 @@
 package main
 
 import (
-	"aoc-2024/datastructures"
 	"bufio"
+	"flag"
 	"os"
 	"strconv"
 	"strings"
@@ -41,108 +41,12 @@ func parseLines(data []string) [][]int {
 	return res
 }
 
-func checkGrowthRate(num1 int, num2 int) bool {
-	abs := datastructures.Abs(num1 - num2)
-	return abs >= 1 && abs <= 3
+func solvePartOne(lines [][]int, bounds StepBounds) int {
+	return countSafe(lines, 0, bounds)
 }
 
-func solvePartOne(lines [][]int) int {
-	count := 0
-	for _, row := range lines {
-		dir := 0
-		if row[0] == row[1] || !checkGrowthRate(row[0], row[1]) {
-			continue
-		}
-		if row[0] > row[1] {
-			dir = 1
-		}
-
-		goodRow := true
-		for idx := 1; idx < len(row); idx++ {
-			if dir == 0 {
-				if row[idx-1] >= row[idx] || !checkGrowthRate(row[idx-1], row[idx]) {
-					goodRow = false
-					break
-				}
-			} else {
-				if row[idx-1] <= row[idx] || !checkGrowthRate(row[idx-1], row[idx]) {
-					goodRow = false
-					break
-				}
-			}
-		}
-
-		if goodRow {
-			count += 1
-		}
-	}
-	return count
-}
-
-func checkAscending(row []int) int {
-	for idx := 1; idx < len(row); idx += 1 {
-		if row[idx] <= row[idx-1] || !checkGrowthRate(row[idx], row[idx-1]) {
-			return idx
-		}
-	}
-	return len(row)
-}
-
-func checkDescending(row []int) int {
-	for idx := 1; idx < len(row); idx += 1 {
-		if row[idx] >= row[idx-1] || !checkGrowthRate(row[idx], row[idx-1]) {
-			return idx
-		}
-	}
-	return len(row)
-}
-
-func removeIndex(line []int, idx int) []int {
-	res := []int{}
-	for jdx := 0; jdx < len(line); jdx++ {
-		if jdx == idx {
-			continue
-		}
-		res = append(res, line[jdx])
-	}
-
-	return res
-}
-
-func solvePartTwo(lines [][]int) int {
-	for idx := 0; idx < len(lines); idx++ {
-		line := lines[idx]
-		asc := checkAscending(line)
-		dsc := checkDescending(line)
-
-		if asc == len(line) || dsc == len(line) {
-			continue
-		}
-
-		removedAsc := removeIndex(line, asc)
-		if checkAscending(removedAsc) == len(removedAsc) {
-			lines[idx] = removedAsc
-			continue
-		}
-
-		removedBeforeAsc := removeIndex(line, asc-1)
-		if checkAscending(removedBeforeAsc) == len(removedBeforeAsc) {
-			lines[idx] = removedBeforeAsc
-			continue
-		}
-
-		removedBeforeDsc := removeIndex(line, dsc-1)
-		if checkDescending(removedBeforeDsc) == len(removedBeforeDsc) {
-			lines[idx] = removedBeforeDsc
-			continue
-		}
-		removedDesc := removeIndex(line, dsc)
-		if checkDescending(removedDesc) == len(removedDesc) {
-			lines[idx] = removedDesc
-		}
-	}
-
-	return solvePartOne(lines)
+func solvePartTwo(lines [][]int, bounds StepBounds) int {
+	return countSafe(lines, 1, bounds)
 }
 
 const IN_FILE_PATH = "./input.txt"
@@ -157,18 +61,52 @@ func main() {
 
 	lines := parseLines(data)
 
-	if len(os.Args) != 2 {
+	tolerance := flag.Int("k", -1, "Number of levels that can be removed, overrides the part's default (0 for part 1, 1 for part 2)")
+	minStep := flag.Int("min-step", DEFAULT_BOUNDS.Min, "Smallest allowed difference between adjacent levels")
+	maxStep := flag.Int("max-step", DEFAULT_BOUNDS.Max, "Largest allowed difference between adjacent levels")
+	explain := flag.String("explain", "", "Print a verdict for every report instead of the count: table or json")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
 		panic("Exactly one arg is expected")
 	}
-	arg := os.Args[1]
+	arg := flag.Arg(0)
 
 	if arg != "1" && arg != "2" {
 		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
 	}
 
-	if arg == "1" {
-		println(solvePartOne(lines))
+	bounds := StepBounds{*minStep, *maxStep}
+
+	if *explain != "" {
+		k := *tolerance
+		if k < 0 && arg == "1" {
+			k = 0
+		} else if k < 0 {
+			k = 1
+		}
+
+		diagnostics := Diagnose(lines, k, bounds)
+		var err error
+		if *explain == "json" {
+			err = WriteDiagnosticsJSON(os.Stdout, diagnostics)
+		} else if *explain == "table" {
+			err = WriteDiagnosticsTable(os.Stdout, diagnostics)
+		} else {
+			panic("Explain can only be table or json")
+		}
+
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	if *tolerance >= 0 {
+		println(countSafe(lines, *tolerance, bounds))
+	} else if arg == "1" {
+		println(solvePartOne(lines, bounds))
 	} else {
-		println(solvePartTwo(lines))
+		println(solvePartTwo(lines, bounds))
 	}
 }
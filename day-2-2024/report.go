@@ -0,0 +1,102 @@
+package main
+
+// Allowed difference between two adjacent levels, both ends inclusive
+type StepBounds struct {
+	Min int
+	Max int
+}
+
+var DEFAULT_BOUNDS = StepBounds{1, 3}
+
+type Verdict struct {
+	Safe      bool
+	Ascending bool
+	// Indices (in the original report) that have to be removed to make it safe, ascending
+	Removed []int
+}
+
+func validStep(from int, to int, ascending bool, bounds StepBounds) bool {
+	diff := to - from
+	if !ascending {
+		diff = -diff
+	}
+	return diff >= bounds.Min && diff <= bounds.Max
+}
+
+// Fewest removals that make the report monotone in the given direction with every step within bounds,
+// if that takes at most k removals. O(n * k): only the last k + 1 levels can precede a kept level.
+func minRemovals(report []int, k int, ascending bool, bounds StepBounds) ([]int, bool) {
+	n := len(report)
+	if n == 0 {
+		return []int{}, true
+	}
+
+	// best[j] = fewest removals among report[:j+1] with report[j] kept as the last level
+	best := make([]int, n)
+	prev := make([]int, n)
+
+	for j := 0; j < n; j++ {
+		// drop everything before j
+		best[j] = j
+		prev[j] = -1
+
+		for i := max(0, j-k-1); i < j; i++ {
+			if !validStep(report[i], report[j], ascending, bounds) {
+				continue
+			}
+			if cost := best[i] + j - i - 1; cost < best[j] {
+				best[j] = cost
+				prev[j] = i
+			}
+		}
+	}
+
+	last := -1
+	for j := max(0, n-k-1); j < n; j++ {
+		if best[j]+n-1-j <= k && (last < 0 || best[j]+n-1-j < best[last]+n-1-last) {
+			last = j
+		}
+	}
+
+	if last < 0 {
+		return nil, false
+	}
+
+	kept := make([]bool, n)
+	for j := last; j >= 0; j = prev[j] {
+		kept[j] = true
+	}
+
+	removed := []int{}
+	for idx, keep := range kept {
+		if !keep {
+			removed = append(removed, idx)
+		}
+	}
+	return removed, true
+}
+
+// A report is safe if, after removing at most k levels, it is strictly ascending or descending
+// with every step within bounds. Reports of zero or one level are always safe.
+func CheckReport(report []int, k int, bounds StepBounds) Verdict {
+	asc, okAsc := minRemovals(report, k, true, bounds)
+	dsc, okDsc := minRemovals(report, k, false, bounds)
+
+	if okAsc && (!okDsc || len(asc) <= len(dsc)) {
+		return Verdict{true, true, asc}
+	}
+	if okDsc {
+		return Verdict{true, false, dsc}
+	}
+	return Verdict{Safe: false}
+}
+
+func countSafe(lines [][]int, k int, bounds StepBounds) int {
+	count := 0
+	for _, row := range lines {
+		if CheckReport(row, k, bounds).Safe {
+			count += 1
+		}
+	}
+	return count
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+var SAMPLE = [][]int{
+	{7, 6, 4, 2, 1},
+	{1, 2, 7, 8, 9},
+	{9, 7, 6, 2, 1},
+	{1, 3, 2, 4, 5},
+	{8, 6, 4, 4, 1},
+	{1, 3, 6, 7, 9},
+}
+
+func TestCountSafeSample(t *testing.T) {
+	if res := countSafe(SAMPLE, 0, DEFAULT_BOUNDS); res != 2 {
+		t.Fatalf("Expected 2 safe reports without removals, found %d instead", res)
+	}
+	if res := countSafe(SAMPLE, 1, DEFAULT_BOUNDS); res != 4 {
+		t.Fatalf("Expected 4 safe reports with one removal, found %d instead", res)
+	}
+}
+
+func TestCheckReport(t *testing.T) {
+	tests := []struct {
+		report    []int
+		k         int
+		safe      bool
+		ascending bool
+		removed   []int
+	}{
+		{[]int{7, 6, 4, 2, 1}, 0, true, false, []int{}},
+		{[]int{1, 3, 2, 4, 5}, 0, false, false, nil},
+		{[]int{1, 3, 2, 4, 5}, 1, true, true, []int{2}},
+		{[]int{8, 6, 4, 4, 1}, 1, true, false, []int{3}},
+		{[]int{1, 2, 7, 8, 9}, 1, false, false, nil},
+		{[]int{1, 5, 9, 10, 11}, 1, false, false, nil},
+		{[]int{1, 5, 9, 10, 11}, 2, true, true, []int{0, 1}},
+		{[]int{10, 1, 2, 20, 3, 4}, 2, true, true, []int{0, 3}},
+		{[]int{5}, 0, true, true, []int{}},
+	}
+
+	for _, test := range tests {
+		verdict := CheckReport(test.report, test.k, DEFAULT_BOUNDS)
+		if verdict.Safe != test.safe {
+			t.Fatalf("Expected %v with k = %d to be safe: %v, it was %v instead", test.report, test.k, test.safe, verdict.Safe)
+		}
+		if !test.safe {
+			continue
+		}
+		if verdict.Ascending != test.ascending || !slices.Equal(verdict.Removed, test.removed) {
+			t.Fatalf("Expected %v with k = %d to be fixed by removing %v (ascending: %v), got %v (ascending: %v) instead",
+				test.report, test.k, test.removed, test.ascending, verdict.Removed, verdict.Ascending)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import "io"
+
+type State struct {
+	Enabled bool
+	Sum     int
+}
+
+// Part of the memory where instructions were all enabled or all disabled, [Start, End)
+type Span struct {
+	Start   int
+	End     int
+	Enabled bool
+}
+
+var MUL = &InstructionSpec{"mul", 2, 3, func(state *State, args []int) int {
+	if !state.Enabled {
+		return 0
+	}
+	return args[0] * args[1]
+}}
+
+var DO = &InstructionSpec{"do", 0, 0, func(state *State, args []int) int {
+	state.Enabled = true
+	return 0
+}}
+
+var DONT = &InstructionSpec{"don't", 0, 0, func(state *State, args []int) int {
+	state.Enabled = false
+	return 0
+}}
+
+// Part 1 only knows about mul, part 2 also about do() and don't()
+var PART_ONE_TABLE = []*InstructionSpec{MUL}
+var PART_TWO_TABLE = []*InstructionSpec{MUL, DO, DONT}
+
+// One executed instruction, Enabled is the state right before it ran
+type TraceEntry struct {
+	Instruction  Instruction
+	Enabled      bool
+	Contribution int
+	Sum          int
+}
+
+type Interpreter struct {
+	Table []*InstructionSpec
+	State State
+	Spans []Span
+	// Called after every instruction when set
+	Trace func(TraceEntry)
+	// Start of the span that is still open
+	spanStart int
+}
+
+// Extra instructions can be appended to the table before calling Run
+func NewInterpreter(table []*InstructionSpec) *Interpreter {
+	return &Interpreter{Table: append([]*InstructionSpec{}, table...), State: State{Enabled: true}}
+}
+
+func (in *Interpreter) exec(inst Instruction) {
+	wasEnabled := in.State.Enabled
+	contribution := inst.Spec.Exec(&in.State, inst.Args)
+	in.State.Sum += contribution
+
+	if in.Trace != nil {
+		in.Trace(TraceEntry{inst, wasEnabled, contribution, in.State.Sum})
+	}
+
+	// the toggling instruction belongs to the span it closes
+	if wasEnabled != in.State.Enabled {
+		end := inst.Offset + inst.Len
+		in.Spans = append(in.Spans, Span{in.spanStart, end, wasEnabled})
+		in.spanStart = end
+	}
+}
+
+// Runs every instruction in r and returns the sum, the spans cover the whole input afterwards
+func (in *Interpreter) Run(r io.Reader) (int, error) {
+	end, err := Lex(r, in.Table, func(inst Instruction) {
+		in.exec(inst)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	in.Spans = append(in.Spans, Span{in.spanStart, end, in.State.Enabled})
+	return in.State.Sum, nil
+}
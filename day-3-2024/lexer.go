@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"io"
+)
+
+// name(arg1,arg2,...) with exactly Arity arguments of 1 to MaxDigits digits each
+type InstructionSpec struct {
+	Name      string
+	Arity     int
+	MaxDigits int
+	// Returns how much the instruction adds to the sum, it can also change the state (enable/disable)
+	Exec func(state *State, args []int) int
+}
+
+func (spec InstructionSpec) maxLen() int {
+	// name + "(" + args with a comma after each one but the last + ")"
+	return len(spec.Name) + 1 + spec.Arity*(spec.MaxDigits+1) + 1
+}
+
+type Instruction struct {
+	Spec *InstructionSpec
+	Args []int
+	// Byte offset in the corrupted memory, lines are joined without separators
+	Offset int
+	Len    int
+}
+
+// Tries to read spec at the start of buf, returns the arguments and the length of the match
+func (spec *InstructionSpec) match(buf []byte) ([]int, int, bool) {
+	if len(buf) < len(spec.Name)+2 || string(buf[:len(spec.Name)]) != spec.Name || buf[len(spec.Name)] != '(' {
+		return nil, 0, false
+	}
+
+	pos := len(spec.Name) + 1
+	args := []int{}
+	for argIdx := 0; argIdx < spec.Arity; argIdx++ {
+		if argIdx > 0 {
+			if pos >= len(buf) || buf[pos] != ',' {
+				return nil, 0, false
+			}
+			pos++
+		}
+
+		num := 0
+		digits := 0
+		for pos < len(buf) && digits < spec.MaxDigits && buf[pos] >= '0' && buf[pos] <= '9' {
+			num = num*10 + int(buf[pos]-'0')
+			digits++
+			pos++
+		}
+		if digits == 0 {
+			return nil, 0, false
+		}
+		args = append(args, num)
+	}
+
+	if pos >= len(buf) || buf[pos] != ')' {
+		return nil, 0, false
+	}
+	return args, pos + 1, true
+}
+
+// Drops line breaks so that an instruction split over two lines still counts,
+// same as if all the lines were concatenated
+type lineJoiner struct {
+	r io.Reader
+}
+
+func (j lineJoiner) Read(p []byte) (int, error) {
+	for {
+		n, err := j.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if b != '\n' && b != '\r' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// Single pass over r, calls visit for every instruction of the table in order and returns the number
+// of bytes read. The lookahead is bounded by the longest instruction, so memory use doesn't depend
+// on the size of the input.
+func Lex(r io.Reader, table []*InstructionSpec, visit func(Instruction)) (int, error) {
+	lookahead := 1
+	for _, spec := range table {
+		lookahead = max(lookahead, spec.maxLen())
+	}
+
+	reader := bufio.NewReaderSize(lineJoiner{r}, max(4096, 2*lookahead))
+	offset := 0
+	for {
+		buf, err := reader.Peek(lookahead)
+		if len(buf) == 0 {
+			if err == io.EOF {
+				return offset, nil
+			}
+			return offset, err
+		}
+
+		size := 1
+		for _, spec := range table {
+			if args, length, ok := spec.match(buf); ok {
+				visit(Instruction{spec, args, offset, length})
+				size = length
+				break
+			}
+		}
+
+		reader.Discard(size)
+		offset += size
+	}
+}
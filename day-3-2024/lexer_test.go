@@ -0,0 +1,42 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func lexArgs(t *testing.T, input string) [][]int {
+	args := [][]int{}
+	_, err := Lex(strings.NewReader(input), PART_ONE_TABLE, func(inst Instruction) {
+		args = append(args, inst.Args)
+	})
+	if err != nil {
+		t.Fatalf("Expected %q to be lexed, got %v instead", input, err)
+	}
+	return args
+}
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		input string
+		args  [][]int
+	}{
+		// the instruction ends with the last byte of the input
+		{"xmul(2,4)", [][]int{{2, 4}}},
+		{"mul(2,4)", [][]int{{2, 4}}},
+		// split over a line break
+		{"mul(2,\n4)", [][]int{{2, 4}}},
+		{"mu\r\nl(3,5)mul(1,1)", [][]int{{3, 5}, {1, 1}}},
+		// too many digits
+		{"mul(1234,5)", [][]int{}},
+		{"mul(2,4", [][]int{}},
+	}
+
+	for _, test := range tests {
+		args := lexArgs(t, test.input)
+		if !slices.EqualFunc(args, test.args, slices.Equal) {
+			t.Fatalf("Expected %q to give the arguments %v, got %v instead", test.input, test.args, args)
+		}
+	}
+}
@@ -1,144 +1,101 @@
 package main
 
 import (
-	"bufio"
+	"flag"
+	"fmt"
+	"io"
 	"os"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
-type Pair struct {
-	fist   int
-	second int
+func solve(r io.Reader, table []*InstructionSpec, trace func(TraceEntry)) (*Interpreter, int, error) {
+	in := NewInterpreter(table)
+	in.Trace = trace
+	res, err := in.Run(r)
+	return in, res, err
 }
 
-type Range = Pair
-
-func readFileAsString(filePath string) ([]string, error) {
-	file, err := os.Open(filePath)
-
-	if err != nil {
-		return nil, err
-	}
-
-	lines := []string{}
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		lines = append(lines, line)
-	}
+func solvePartOne(r io.Reader, trace func(TraceEntry)) (*Interpreter, int, error) {
+	return solve(r, PART_ONE_TABLE, trace)
+}
 
-	return lines, nil
+func solvePartTwo(r io.Reader, trace func(TraceEntry)) (*Interpreter, int, error) {
+	return solve(r, PART_TWO_TABLE, trace)
 }
 
-func combineLines(lines []string) string {
-	res := ""
-	for _, line := range lines {
-		res += line
+func printSpans(spans []Span) {
+	for _, span := range spans {
+		state := "enabled"
+		if !span.Enabled {
+			state = "disabled"
+		}
+		fmt.Printf("%-8s [%d, %d)\n", state, span.Start, span.End)
 	}
-	return res
 }
 
-func findMulPairs(str string) []Pair {
-	pairs := []Pair{}
-	r := regexp.MustCompile(`mul\((\d{1,3},\d{1,3})\)`)
-
-	matches := r.FindAllStringSubmatch(str, -1)
+const IN_FILE_PATH = "./input.txt"
 
-	for _, match := range matches {
-		numbers := strings.Split(match[1], ",")
-		num1, _ := strconv.Atoi(numbers[0])
-		num2, _ := strconv.Atoi(numbers[1])
+func main() {
+	showSpans := flag.Bool("spans", false, "Print the enabled and disabled parts of the memory")
+	showTrace := flag.Bool("trace", false, "Print every instruction with its offset, whether it was enabled and what it added")
+	highlight := flag.Bool("highlight", false, "Print the memory with the recognized instructions colored")
+	flag.Parse()
 
-		pairs = append(pairs, Pair{num1, num2})
+	if flag.NArg() != 1 {
+		panic("Exactly one arg is expected")
 	}
+	arg := flag.Arg(0)
 
-	return pairs
-}
-
-func solvePartOne(str string) int {
-	res := 0
-	for _, pair := range findMulPairs(str) {
-		res += pair.fist * pair.second
+	if arg != "1" && arg != "2" {
+		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
 	}
-	return res
-}
 
-func stringHasFrom(str string, has string, idx int) bool {
-	if idx+len(has) >= len(str) {
-		return false
+	file, err := os.Open(IN_FILE_PATH)
+	if err != nil {
+		println(err.Error())
+		panic("Error reading from " + IN_FILE_PATH)
 	}
-	return str[idx:idx+len(has)] == has
-}
-
-func isDo(str string, idx int) bool {
-	return stringHasFrom(str, "do()", idx)
-}
-
-func isDont(str string, idx int) bool {
-	return stringHasFrom(str, "don't()", idx)
-}
+	defer file.Close()
 
-func solvePartTwo(str string) int {
-	goodRanges := []Range{}
-	currStart := 0
-	state := "OK"
-	idx := 0
-	doSize := 4
-	dontSize := 7
-
-	for idx < len(str) {
-		if state == "OK" && isDont(str, idx) {
-			idx += dontSize
-			goodRanges = append(goodRanges, Range{currStart, idx})
-			state = "NOK"
-			continue
+	entries := []TraceEntry{}
+	var trace func(TraceEntry)
+	if *showTrace || *highlight {
+		if *showTrace {
+			WriteTraceHeader(os.Stdout)
 		}
-		if state == "NOK" && isDo(str, idx) {
-			idx += doSize
-			currStart = idx
-			state = "OK"
-			continue
+		trace = func(entry TraceEntry) {
+			if *showTrace {
+				WriteTraceEntry(os.Stdout, entry)
+			}
+			if *highlight {
+				entries = append(entries, entry)
+			}
 		}
-		idx += 1
-	}
-
-	if state == "OK" {
-		goodRanges = append(goodRanges, Range{currStart, idx})
 	}
 
-	res := 0
-	for _, rang := range goodRanges {
-		res += solvePartOne(str[rang.fist:rang.second])
+	var in *Interpreter
+	var res int
+	if arg == "1" {
+		in, res, err = solvePartOne(file, trace)
+	} else {
+		in, res, err = solvePartTwo(file, trace)
 	}
-	return res
-}
-
-const IN_FILE_PATH = "./input.txt"
-
-func main() {
-	data, err := readFileAsString(IN_FILE_PATH)
 
 	if err != nil {
-		println(err)
+		println(err.Error())
 		panic("Error reading from " + IN_FILE_PATH)
 	}
 
-	if len(os.Args) != 2 {
-		panic("Exactly one arg is expected")
+	if *showSpans {
+		printSpans(in.Spans)
 	}
-	arg := os.Args[1]
 
-	if arg != "1" && arg != "2" {
-		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
-	}
-
-	combinedLines := combineLines(data)
-	if arg == "1" {
-		println(solvePartOne(combinedLines))
-	} else {
-		println(solvePartTwo(combinedLines))
+	if *highlight {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			panic(err)
+		}
+		if err := WriteHighlighted(os.Stdout, file, entries); err != nil {
+			panic(err)
+		}
 	}
+	println(res)
 }
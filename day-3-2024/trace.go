@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	ANSI_RESET  = "\033[0m"
+	ANSI_GREEN  = "\033[32;1m"
+	ANSI_RED    = "\033[31m"
+	ANSI_YELLOW = "\033[33;1m"
+	ANSI_DIM    = "\033[2m"
+)
+
+func (inst Instruction) String() string {
+	args := []string{}
+	for _, arg := range inst.Args {
+		args = append(args, fmt.Sprint(arg))
+	}
+	return inst.Spec.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+func WriteTraceHeader(w io.Writer) {
+	fmt.Fprintf(w, "%10s  %-16s  %-8s  %12s  %12s\n", "OFFSET", "INSTRUCTION", "ENABLED", "CONTRIBUTION", "SUM")
+}
+
+func WriteTraceEntry(w io.Writer, entry TraceEntry) {
+	fmt.Fprintf(w, "%10d  %-16s  %-8t  %12d  %12d\n",
+		entry.Instruction.Offset, entry.Instruction, entry.Enabled, entry.Contribution, entry.Sum)
+}
+
+func highlightColor(entry TraceEntry) string {
+	if entry.Instruction.Spec != MUL {
+		return ANSI_YELLOW
+	}
+	if entry.Enabled {
+		return ANSI_GREEN
+	}
+	return ANSI_RED
+}
+
+// Copies the memory to w with every traced instruction colored: enabled mul in green, disabled
+// mul in red, anything else in yellow, and the corrupted bytes in between dimmed.
+// memory must be the same input the entries were traced from, entries in offset order.
+func WriteHighlighted(w io.Writer, memory io.Reader, entries []TraceEntry) error {
+	reader := bufio.NewReader(lineJoiner{memory})
+	writer := bufio.NewWriter(w)
+
+	copyN := func(n int) error {
+		_, err := io.CopyN(writer, reader, int64(n))
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	offset := 0
+	for _, entry := range entries {
+		inst := entry.Instruction
+		if inst.Offset > offset {
+			writer.WriteString(ANSI_DIM)
+			if err := copyN(inst.Offset - offset); err != nil {
+				return err
+			}
+			writer.WriteString(ANSI_RESET)
+		}
+
+		writer.WriteString(highlightColor(entry))
+		if err := copyN(inst.Len); err != nil {
+			return err
+		}
+		writer.WriteString(ANSI_RESET)
+		offset = inst.Offset + inst.Len
+	}
+
+	writer.WriteString(ANSI_DIM)
+	if _, err := io.Copy(writer, reader); err != nil {
+		return err
+	}
+	writer.WriteString(ANSI_RESET + "\n")
+	return writer.Flush()
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"bufio"
+	"flag"
+	"fmt"
 	"os"
+	"strings"
 )
 
 type Pair struct {
@@ -34,119 +37,59 @@ func validCoords(matrix []string, x int, y int) bool {
 	return x >= 0 && x < len(matrix) && y >= 0 && y < len(matrix[0])
 }
 
-func checkMAS(matrix []string, x int, y int) bool {
-	if matrix[x][y] != 'M' && matrix[x][y] != 'S' {
-		return false
-	}
+func solvePartOne(matrix []string) int {
+	return len(FindWords(matrix, []string{"XMAS"}, ALL_DIRECTIONS))
+}
 
-	nextHeadCoords := Pair{x, y + 2}
-	if !validCoords(matrix, nextHeadCoords.x, nextHeadCoords.y) ||
-		(matrix[nextHeadCoords.x][nextHeadCoords.y] != 'M' && matrix[nextHeadCoords.x][nextHeadCoords.y] != 'S') {
-		return false
-	}
+var X_MAS = MustParseTemplate("M.S/.A./M.S")
 
-	aCoords := Pair{x + 1, y + 1}
+func solvePartTwo(matrix []string) int {
+	return len(FindTemplate(matrix, X_MAS))
+}
 
-	if !validCoords(matrix, aCoords.x, aCoords.y) || matrix[aCoords.x][aCoords.y] != 'A' {
-		return false
-	}
+func main() {
+	data, err := readFileAsString(IN_FILE_PATH)
 
-	// first branch
-	if matrix[x][y] == 'M' {
-		sCoords := Pair{x + 2, y + 2}
-		if !validCoords(matrix, sCoords.x, sCoords.y) || matrix[sCoords.x][sCoords.y] != 'S' {
-			return false
-		}
+	if err != nil {
+		println(err)
+		panic("Error reading from " + IN_FILE_PATH)
 	}
 
-	if matrix[x][y] == 'S' {
-		mCoords := Pair{x + 2, y + 2}
-		if !validCoords(matrix, mCoords.x, mCoords.y) || matrix[mCoords.x][mCoords.y] != 'M' {
-			return false
-		}
-	}
+	wordList := flag.String("words", "", "Comma separated words to look for instead of solving a part")
+	directions := flag.String("dirs", "all", "Comma separated directions the words can be read along: orthogonal, diagonal, reversed or all")
+	templatesPath := flag.String("templates", "", "File with one pattern per line (like M.S/.A./M.S) to count instead of solving a part")
+	flag.Parse()
 
-	// second branch
-	if matrix[nextHeadCoords.x][nextHeadCoords.y] == 'M' {
-		sCoords := Pair{x + 2, y}
-		if !validCoords(matrix, sCoords.x, sCoords.y) || matrix[sCoords.x][sCoords.y] != 'S' {
-			return false
+	if *templatesPath != "" {
+		templates, err := LoadTemplates(*templatesPath)
+		if err != nil {
+			panic(err)
 		}
-	}
 
-	if matrix[nextHeadCoords.x][nextHeadCoords.y] == 'S' {
-		mCoords := Pair{x + 2, y}
-		if !validCoords(matrix, mCoords.x, mCoords.y) || matrix[mCoords.x][mCoords.y] != 'M' {
-			return false
+		for _, t := range templates {
+			fmt.Printf("%s: %d matches\n", t, len(FindTemplate(data, t)))
 		}
+		return
 	}
 
-	return true
-}
-
-func countWordsFrom(matrix []string, word string, x int, y int) int {
-	dx := []int{1, -1, 0, 0, 1, -1, -1, 1}
-	dy := []int{1, -1, 1, -1, 0, 0, 1, -1}
-
-	count := 0
-	for idx := 0; idx < len(dx); idx++ {
-		wordPos := 1
-		xx := x
-		yy := y
-		for wordPos < len(word) {
-			nX := xx + dx[idx]
-			nY := yy + dy[idx]
-			if !validCoords(matrix, nX, nY) || matrix[nX][nY] != word[wordPos] {
-				break
-			}
-			wordPos += 1
-			xx = nX
-			yy = nY
-		}
-		if wordPos == len(word) {
-			count += 1
+	if *wordList != "" {
+		dirs, err := ParseDirectionSet(*directions)
+		if err != nil {
+			panic(err)
 		}
-	}
-
-	return count
-}
 
-func solvePartOne(matrix []string) int {
-	total_count := 0
-	for idx := 0; idx < len(matrix); idx++ {
-		for jdx := 0; jdx < len(matrix[idx]); jdx++ {
-			if matrix[idx][jdx] == 'X' {
-				total_count += countWordsFrom(matrix, "XMAS", idx, jdx)
-			}
-		}
-	}
-	return total_count
-}
-
-func solvePartTwo(matrix []string) int {
-	total_count := 0
-	for idx := 0; idx < len(matrix); idx++ {
-		for jdx := 0; jdx < len(matrix[idx]); jdx++ {
-			if checkMAS(matrix, idx, jdx) {
-				total_count += 1
-			}
+		matches := FindWords(data, strings.Split(*wordList, ","), dirs)
+		for _, match := range matches {
+			fmt.Printf("%s at (%d, %d) going (%d, %d)\n", match.Word, match.Start.x, match.Start.y, match.Dir.x, match.Dir.y)
 		}
-	}
-	return total_count
-}
-
-func main() {
-	data, err := readFileAsString(IN_FILE_PATH)
-
-	if err != nil {
-		println(err)
-		panic("Error reading from " + IN_FILE_PATH)
+		fmt.Printf("%d matches, %d cells shared by more than one match\n", len(matches), CountOverlaps(matches))
+		return
 	}
 
-	if len(os.Args) != 2 {
+	if flag.NArg() != 1 {
 		panic("Exactly one arg is expected")
 	}
-	arg := os.Args[1]
+	arg := flag.Arg(0)
 
 	if arg != "1" && arg != "2" {
 		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
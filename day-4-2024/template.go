@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const WILDCARD = '.'
+
+// Small ASCII pattern, '.' matches any letter. Written on one line with '/' between rows: M.S/.A./M.S
+type Template struct {
+	Rows []string
+}
+
+func ParseTemplate(s string) (Template, error) {
+	rows := strings.Split(strings.TrimSpace(s), "/")
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return Template{}, fmt.Errorf("Template %q is empty", s)
+	}
+	if strings.Trim(strings.Join(rows, ""), string(WILDCARD)) == "" {
+		return Template{}, fmt.Errorf("Template %q has only wildcards, it would match everywhere", s)
+	}
+
+	// short rows are padded with wildcards so that the template is a rectangle
+	for idx, row := range rows {
+		rows[idx] = row + strings.Repeat(string(WILDCARD), width-len(row))
+	}
+	return Template{rows}, nil
+}
+
+func MustParseTemplate(s string) Template {
+	t, err := ParseTemplate(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// One template per line, empty lines and lines starting with # are skipped
+func LoadTemplates(filePath string) ([]Template, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	templates := []Template{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		t, err := ParseTemplate(line)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, scanner.Err()
+}
+
+func (t Template) String() string {
+	return strings.Join(t.Rows, "/")
+}
+
+func (t Template) height() int {
+	return len(t.Rows)
+}
+
+func (t Template) width() int {
+	return len(t.Rows[0])
+}
+
+// Quarter turn clockwise
+func (t Template) Rotate() Template {
+	rows := []string{}
+	for col := 0; col < t.width(); col++ {
+		row := []byte{}
+		for line := t.height() - 1; line >= 0; line-- {
+			row = append(row, t.Rows[line][col])
+		}
+		rows = append(rows, string(row))
+	}
+	return Template{rows}
+}
+
+// Left to right mirror image
+func (t Template) Mirror() Template {
+	rows := []string{}
+	for _, row := range t.Rows {
+		reversed := []byte(row)
+		slices.Reverse(reversed)
+		rows = append(rows, string(reversed))
+	}
+	return Template{rows}
+}
+
+// The distinct templates among the 4 rotations of t and of its mirror image
+func (t Template) Variants() []Template {
+	res := []Template{}
+	seen := map[string]bool{}
+	for _, base := range []Template{t, t.Mirror()} {
+		curr := base
+		for turn := 0; turn < 4; turn++ {
+			if !seen[curr.String()] {
+				seen[curr.String()] = true
+				res = append(res, curr)
+			}
+			curr = curr.Rotate()
+		}
+	}
+	return res
+}
+
+type TemplateMatch struct {
+	Variant Template
+	// Position of the top left corner of the variant
+	At Pair
+}
+
+// The whole rectangle of the template has to be inside the grid, wildcards included
+func templateAt(matrix []string, t Template, at Pair) bool {
+	for idx, row := range t.Rows {
+		x := at.x + idx
+		if !validCoords(matrix, x, at.y) || !validCoords(matrix, x, at.y+len(row)-1) {
+			return false
+		}
+		for jdx := 0; jdx < len(row); jdx++ {
+			if row[jdx] != WILDCARD && matrix[x][at.y+jdx] != row[jdx] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Key made of the non wildcard cells a match covers, two variants that match the
+// exact same letters (a symmetric shape) produce the same key
+func matchKey(m TemplateMatch) string {
+	var sb strings.Builder
+	for idx, row := range m.Variant.Rows {
+		for jdx := 0; jdx < len(row); jdx++ {
+			if row[jdx] != WILDCARD {
+				fmt.Fprintf(&sb, "%d,%d;", m.At.x+idx, m.At.y+jdx)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Every placement of t in any rotation or mirror image, counting each set of matched cells once
+func FindTemplate(matrix []string, t Template) []TemplateMatch {
+	variants := t.Variants()
+	seen := map[string]bool{}
+	res := []TemplateMatch{}
+
+	for idx := 0; idx < len(matrix); idx++ {
+		for jdx := 0; jdx < len(matrix[idx]); jdx++ {
+			for _, variant := range variants {
+				if !templateAt(matrix, variant, Pair{idx, jdx}) {
+					continue
+				}
+				match := TemplateMatch{variant, Pair{idx, jdx}}
+				key := matchKey(match)
+				if !seen[key] {
+					seen[key] = true
+					res = append(res, match)
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var SAMPLE = strings.Split(`MMMSXXMASM
+MSAMXMSMSA
+AMXSXMAAMM
+MSAMASMSMX
+XMASAMXAMM
+XXAMMXXAMA
+SMSMSASXSS
+SAXAMASAAA
+MAMMMXMMMM
+MXMXAXMASX`, "\n")
+
+func TestFindTemplateSample(t *testing.T) {
+	if variants := X_MAS.Variants(); len(variants) != 4 {
+		t.Fatalf("Expected 4 variants of %s, the mirror images being rotations, found %v instead", X_MAS, variants)
+	}
+
+	if matches := FindTemplate(SAMPLE, X_MAS); len(matches) != 9 {
+		t.Fatalf("Expected 9 matches of %s, found %d instead", X_MAS, len(matches))
+	}
+}
+
+func TestFindTemplateSymmetric(t *testing.T) {
+	// .A. and its rotation cover the same single cell, a cell in the middle row and column counts once
+	matrix := []string{"AAA", "AAA", "AAA"}
+	if matches := FindTemplate(matrix, MustParseTemplate(".A.")); len(matches) != 5 {
+		t.Fatalf("Expected 5 matches of .A., found %v instead", matches)
+	}
+}
+
+func TestFindTemplateInsideGrid(t *testing.T) {
+	matrix := []string{"MAS"}
+	if matches := FindTemplate(matrix, MustParseTemplate("MAS.")); len(matches) != 0 {
+		t.Fatalf("Expected MAS. to be too wide for the grid, found %v instead", matches)
+	}
+	if matches := FindTemplate(matrix, MustParseTemplate(".A.")); len(matches) != 1 {
+		t.Fatalf("Expected one match of .A., found %v instead", matches)
+	}
+
+	if _, err := ParseTemplate("../.."); err == nil {
+		t.Fatalf("Expected a template of only wildcards to be rejected")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DirectionSet int
+
+const (
+	// Left to right and top to bottom
+	ORTHOGONAL DirectionSet = 1 << iota
+	// Both diagonals, going down
+	DIAGONAL
+	// Adds the opposite of every direction above
+	REVERSED
+)
+
+const ALL_DIRECTIONS = ORTHOGONAL | DIAGONAL | REVERSED
+
+var DIRECTION_NAMES = map[string]DirectionSet{
+	"orthogonal": ORTHOGONAL,
+	"diagonal":   DIAGONAL,
+	"reversed":   REVERSED,
+	"all":        ALL_DIRECTIONS,
+}
+
+// Parses a comma separated list of direction set names
+func ParseDirectionSet(s string) (DirectionSet, error) {
+	res := DirectionSet(0)
+	for _, name := range strings.Split(s, ",") {
+		set, ok := DIRECTION_NAMES[strings.TrimSpace(name)]
+		if !ok {
+			return 0, fmt.Errorf("Unknown direction set %q, expected orthogonal, diagonal, reversed or all", name)
+		}
+		res |= set
+	}
+	return res, nil
+}
+
+// Offsets a word can be read along, REVERSED on its own gives nothing
+func (s DirectionSet) Offsets() []Pair {
+	res := []Pair{}
+	if s&ORTHOGONAL != 0 {
+		res = append(res, Pair{0, 1}, Pair{1, 0})
+	}
+	if s&DIAGONAL != 0 {
+		res = append(res, Pair{1, 1}, Pair{1, -1})
+	}
+	if s&REVERSED != 0 {
+		for _, offset := range res {
+			res = append(res, Pair{-offset.x, -offset.y})
+		}
+	}
+	return res
+}
+
+type WordMatch struct {
+	Word  string
+	Start Pair
+	Dir   Pair
+}
+
+// Cells covered by the match, first letter first
+func (m WordMatch) Cells() []Pair {
+	res := []Pair{}
+	for idx := 0; idx < len(m.Word); idx++ {
+		res = append(res, Pair{m.Start.x + idx*m.Dir.x, m.Start.y + idx*m.Dir.y})
+	}
+	return res
+}
+
+func wordAt(matrix []string, word string, start Pair, dir Pair) bool {
+	for idx := 0; idx < len(word); idx++ {
+		x := start.x + idx*dir.x
+		y := start.y + idx*dir.y
+		if !validCoords(matrix, x, y) || matrix[x][y] != word[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Every placement of every word along the allowed directions, ordered by start cell.
+// A palindrome shows up twice with REVERSED, once from each end.
+func FindWords(matrix []string, words []string, dirs DirectionSet) []WordMatch {
+	// a word listed twice would report every one of its matches twice
+	byFirstLetter := map[byte][]string{}
+	seen := map[string]bool{}
+	for _, word := range words {
+		if len(word) > 0 && !seen[word] {
+			seen[word] = true
+			byFirstLetter[word[0]] = append(byFirstLetter[word[0]], word)
+		}
+	}
+
+	offsets := dirs.Offsets()
+	res := []WordMatch{}
+	for idx := 0; idx < len(matrix); idx++ {
+		for jdx := 0; jdx < len(matrix[idx]); jdx++ {
+			for _, word := range byFirstLetter[matrix[idx][jdx]] {
+				for _, dir := range offsets {
+					// one letter words would match once per direction otherwise
+					if len(word) == 1 && dir != offsets[0] {
+						continue
+					}
+					if wordAt(matrix, word, Pair{idx, jdx}, dir) {
+						res = append(res, WordMatch{word, Pair{idx, jdx}, dir})
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// How many matches go through each cell
+func CellUsage(matches []WordMatch) map[Pair]int {
+	usage := map[Pair]int{}
+	for _, match := range matches {
+		for _, cell := range match.Cells() {
+			usage[cell] += 1
+		}
+	}
+	return usage
+}
+
+// Number of cells shared by at least two matches
+func CountOverlaps(matches []WordMatch) int {
+	count := 0
+	for _, used := range CellUsage(matches) {
+		if used > 1 {
+			count += 1
+		}
+	}
+	return count
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
-	"aoc-2024/datastructures"
 	"bufio"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -59,109 +61,39 @@ func makeGraphAndUpdates(data []string) (map[int][]int, [][]int) {
 	return graph, updates
 }
 
-func getPosInArray(nodes []int, val int) int {
-	for idx, n := range nodes {
-		if n == val {
-			return idx
-		}
-	}
-	return -1
-}
-
-func checkIsTopoSorted(graph map[int][]int, nodes []int) bool {
-	for idx, node := range nodes {
-		neighbours, ok := graph[node]
-		if !ok {
-			continue
-		}
-		for _, neighbour := range neighbours {
-			jdx := getPosInArray(nodes, neighbour)
-			if jdx < 0 {
-				continue
-			}
-
-			if jdx < idx {
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
-func solvePartOne(graph map[int][]int, updates [][]int) int {
+func solvePartOne(rules RuleSet, updates [][]int) int {
 	sum := 0
 	for _, update := range updates {
-		if checkIsTopoSorted(graph, update) {
+		_, ok, err := rules.Validate(update)
+		if err != nil {
+			panic(err)
+		}
+		if ok {
 			sum += update[len(update)/2]
 		}
 	}
 	return sum
 }
 
-func getTopoSort(graph map[int][]int) []int {
-	deg := map[int]int{}
-	for node := range graph {
-		deg[node] = 0
-	}
-
-	for _, edges := range graph {
-		for _, neighbour := range edges {
-			deg[neighbour] += 1
-		}
-	}
-
-	queue := datastructures.Queue{}
-	for node, inDegree := range deg {
-		if inDegree == 0 {
-			queue.Enqueue(node)
-		}
-	}
-
-	topoSort := []int{}
-	for len(queue) > 0 {
-		node := queue.Dequeue()
-		topoSort = append(topoSort, node.(int))
-		for _, neighbour := range graph[node.(int)] {
-			deg[neighbour] -= 1
-			if deg[neighbour] == 0 {
-				queue.Enqueue(neighbour)
-			}
+func solvePartTwo(rules RuleSet, updates [][]int, fix func([]int) ([]int, error)) int {
+	sum := 0
+	for idx, update := range updates {
+		_, ok, err := rules.Validate(update)
+		if err != nil {
+			panic(err)
 		}
-	}
-	return topoSort
-}
-
-func fixUpdate(graph map[int][]int, update []int) []int {
-	filteredGraph := map[int][]int{}
-	for node, edges := range graph {
-		pos := getPosInArray(update, node)
-		if pos < 0 {
+		if ok {
 			continue
 		}
-		// filteredGraph[node] = []int{}
-		toKeep := []int{}
-		for _, neighbour := range edges {
-			pos := getPosInArray(update, neighbour)
-			if pos < 0 {
-				continue
-			}
-			toKeep = append(toKeep, neighbour)
-		}
-		filteredGraph[node] = toKeep
-	}
-
-	return getTopoSort(filteredGraph)
-}
 
-func solvePartTwo(graph map[int][]int, updates [][]int) int {
-	sum := 0
-	for _, update := range updates {
-		if checkIsTopoSorted(graph, update) {
-			continue
+		fixed, err := fix(update)
+		var orderErr *OrderingError
+		if errors.As(err, &orderErr) && fixed != nil {
+			fmt.Fprintf(os.Stderr, "Update %d: %s, fixed with a topological sort instead\n", idx+1, err)
+		} else if err != nil {
+			panic(err)
 		}
-		update = fixUpdate(graph, update)
-		sum += update[len(update)/2]
+		sum += fixed[len(fixed)/2]
 	}
 	return sum
 }
@@ -177,19 +109,55 @@ func main() {
 	}
 
 	graph, updates := makeGraphAndUpdates(data)
+	rules := NewRuleSet(graph)
+
+	explain := flag.Bool("explain", false, "Print a cycle in the rules if there is one, and for every invalid update the first broken rule and whether the rules order its pages well enough to sort it")
+	fixWith := flag.String("fix", "sort", "How to fix invalid updates: sort (with the rules as a comparator) or topo (topological sort)")
+	flag.Parse()
+
+	fix := rules.Sort
+	switch *fixWith {
+	case "sort":
+	case "topo":
+		fix = rules.Fix
+	default:
+		panic("Unknown fix method " + *fixWith)
+	}
+
+	if *explain {
+		if cycle := rules.FindCycle(nil); cycle != nil {
+			fmt.Println((&CycleError{cycle}).Error())
+		} else {
+			fmt.Println("Rules have no cycle")
+		}
+
+		for idx, update := range updates {
+			rule, ok, err := rules.Validate(update)
+			if err != nil {
+				fmt.Printf("Update %d is invalid: %s\n", idx+1, err)
+			} else if !ok {
+				fmt.Printf("Update %d breaks rule %s\n", idx+1, rule)
+				if err := rules.CheckOrdering(update); err != nil {
+					fmt.Printf("Update %d can't be sorted with the rules: %s\n", idx+1, err)
+				} else {
+					fmt.Printf("Update %d can be sorted with the rules\n", idx+1)
+				}
+			}
+		}
+	}
 
-	if len(os.Args) != 2 {
+	if flag.NArg() != 1 {
 		panic("Exactly one arg is expected")
 	}
-	arg := os.Args[1]
+	arg := flag.Arg(0)
 
 	if arg != "1" && arg != "2" {
 		panic("Arg can only be 1 or 2 for part 1 ore part 2 of the problem respectively")
 	}
 
 	if arg == "1" {
-		println(solvePartOne(graph, updates))
+		println(solvePartOne(rules, updates))
 	} else {
-		println(solvePartTwo(graph, updates))
+		println(solvePartTwo(rules, updates, fix))
 	}
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// The order the rules put on the pages of one update. Rules are not transitive on their own
+// (1|2 and 2|3 say nothing about 1 and 3), so a page is before another when a chain of rules
+// through pages of the update leads from one to the other.
+type PageOrder struct {
+	pages []int
+	less  map[Rule]bool
+}
+
+// Transitive closure of the rules restricted to the given pages, which must all be different
+func (r RuleSet) OrderOn(pages []int) (PageOrder, error) {
+	pos, err := pagePositions(pages)
+	if err != nil {
+		return PageOrder{}, err
+	}
+
+	less := map[Rule]bool{}
+	for _, start := range pages {
+		stack := []int{start}
+		seen := map[int]bool{}
+		for len(stack) > 0 {
+			page := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			for _, after := range r.graph[page] {
+				if _, ok := pos[after]; !ok || seen[after] {
+					continue
+				}
+				seen[after] = true
+				less[Rule{start, after}] = true
+				stack = append(stack, after)
+			}
+		}
+	}
+	return PageOrder{slices.Clone(pages), less}, nil
+}
+
+// Comparison function for slices.SortFunc and friends, negative when a is before b,
+// positive when b is before a and 0 when the rules don't relate them.
+// Only meaningful when Check succeeds.
+func (o PageOrder) Cmp(a int, b int) int {
+	if o.less[Rule{a, b}] {
+		return -1
+	}
+	if o.less[Rule{b, a}] {
+		return 1
+	}
+	return 0
+}
+
+// Which strict weak ordering property a set of pages breaks
+type OrderingViolation int
+
+const (
+	IRREFLEXIVITY OrderingViolation = iota
+	ASYMMETRY
+	TRANSITIVITY
+	INCOMPARABILITY
+)
+
+func (v OrderingViolation) String() string {
+	return [...]string{"irreflexivity", "asymmetry", "transitivity", "transitivity of incomparability"}[v]
+}
+
+// Returned by Check, Pages are the one, two or three pages the broken property is about
+type OrderingError struct {
+	Violation OrderingViolation
+	Pages     []int
+}
+
+func (e *OrderingError) Error() string {
+	return fmt.Sprintf("Rules break %s on pages %v", e.Violation, e.Pages)
+}
+
+// Checks that the order is a strict weak ordering, which is what slices.SortFunc needs from Cmp
+// to give a sorted result. Takes O(n^3) for n pages.
+func (o PageOrder) Check() error {
+	pages := slices.Sorted(slices.Values(o.pages))
+	less := func(a int, b int) bool { return o.less[Rule{a, b}] }
+	incomparable := func(a int, b int) bool { return !less(a, b) && !less(b, a) }
+
+	for _, a := range pages {
+		if less(a, a) {
+			return &OrderingError{IRREFLEXIVITY, []int{a}}
+		}
+	}
+
+	for idx, a := range pages {
+		for _, b := range pages[idx+1:] {
+			if less(a, b) && less(b, a) {
+				return &OrderingError{ASYMMETRY, []int{a, b}}
+			}
+		}
+	}
+
+	for _, a := range pages {
+		for _, b := range pages {
+			if a == b {
+				continue
+			}
+			for _, c := range pages {
+				if c == a || c == b {
+					continue
+				}
+				if less(a, b) && less(b, c) && !less(a, c) {
+					return &OrderingError{TRANSITIVITY, []int{a, b, c}}
+				}
+				if incomparable(a, b) && incomparable(b, c) && !incomparable(a, c) {
+					return &OrderingError{INCOMPARABILITY, []int{a, b, c}}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Checks that the rules are a strict weak ordering on the given pages
+func (r RuleSet) CheckOrdering(pages []int) error {
+	order, err := r.OrderOn(pages)
+	if err != nil {
+		return err
+	}
+	return order.Check()
+}
+
+// Fixes the update by sorting it with the Cmp of its PageOrder, once Check approved it. When the order
+// is not a strict weak ordering, e.g. 1|2 with a third page no rule mentions, the comparator can't be used:
+// the update is fixed with a topological sort instead and returned together with the *OrderingError.
+func (r RuleSet) Sort(update []int) ([]int, error) {
+	order, err := r.OrderOn(update)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Check(); err != nil {
+		fixed, fixErr := r.Fix(update)
+		if fixErr != nil {
+			return nil, fixErr
+		}
+		return fixed, err
+	}
+
+	sorted := slices.Clone(update)
+	slices.SortStableFunc(sorted, order.Cmp)
+	return sorted, nil
+}
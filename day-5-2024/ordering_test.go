@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func checkViolation(t *testing.T, err error, violation OrderingViolation) {
+	var orderErr *OrderingError
+	if !errors.As(err, &orderErr) || orderErr.Violation != violation {
+		t.Fatalf("Expected the check to fail with %s, got %v instead", violation, err)
+	}
+}
+
+func TestCheckOrdering(t *testing.T) {
+	rules, updates := sampleRules()
+	for _, update := range updates {
+		if err := rules.CheckOrdering(update); err != nil {
+			t.Fatalf("Expected the sample rules to totally order %v, got %v instead", update, err)
+		}
+	}
+
+	// 1|2 through 2|3 is enough, the order is transitively closed
+	chain := NewRuleSet(map[int][]int{1: {2}, 2: {3}})
+	order, err := chain.OrderOn([]int{3, 2, 1})
+	if err != nil || order.Check() != nil || order.Cmp(1, 3) >= 0 || order.Cmp(3, 1) <= 0 {
+		t.Fatalf("Expected 1 to be before 3, got %d (%v, %v) instead", order.Cmp(1, 3), err, order.Check())
+	}
+
+	partial := NewRuleSet(map[int][]int{1: {2}})
+	checkViolation(t, partial.CheckOrdering([]int{2, 3, 1}), INCOMPARABILITY)
+
+	cyclic := NewRuleSet(map[int][]int{1: {2}, 2: {1}})
+	checkViolation(t, cyclic.CheckOrdering([]int{1, 2}), IRREFLEXIVITY)
+}
+
+func TestSort(t *testing.T) {
+	rules, updates := sampleRules()
+	for _, update := range updates {
+		sorted, errSort := rules.Sort(update)
+		fixed, errFix := rules.Fix(update)
+		if errSort != nil || errFix != nil || !slices.Equal(sorted, fixed) {
+			t.Fatalf("Expected sorting %v to give %v, it gave %v (%v, %v) instead", update, fixed, sorted, errSort, errFix)
+		}
+	}
+
+	if res := solvePartTwo(rules, updates, rules.Sort); res != 123 {
+		t.Fatalf("Expected part two to be 123, it was %d instead", res)
+	}
+
+	partial := NewRuleSet(map[int][]int{1: {2}})
+	sorted, err := partial.Sort([]int{2, 3, 1})
+	checkViolation(t, err, INCOMPARABILITY)
+	if !slices.Equal(sorted, []int{3, 1, 2}) {
+		t.Fatalf("Expected the topological sort fallback to give [3 1 2], it gave %v instead", sorted)
+	}
+}
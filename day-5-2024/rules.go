@@ -0,0 +1,192 @@
+package main
+
+import (
+	"aoc-2024/datastructures"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Page Before has to be printed before page After
+type Rule struct {
+	Before int
+	After  int
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%d|%d", r.Before, r.After)
+}
+
+// Returned when the rules that apply to a set of pages can't all hold at once
+type CycleError struct {
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	if len(e.Cycle) == 0 {
+		return "Rules contain a cycle"
+	}
+
+	parts := []string{}
+	for _, page := range append(e.Cycle, e.Cycle[0]) {
+		parts = append(parts, fmt.Sprint(page))
+	}
+	return "Rules contain a cycle: " + strings.Join(parts, " -> ")
+}
+
+// Returned when an update lists the same page more than once, no ordering of it can satisfy the rules
+type DuplicatePageError struct {
+	Page int
+}
+
+func (e *DuplicatePageError) Error() string {
+	return fmt.Sprintf("Page %d appears more than once in the update", e.Page)
+}
+
+// Position of every page in the update
+func pagePositions(update []int) (map[int]int, error) {
+	pos := make(map[int]int, len(update))
+	for idx, page := range update {
+		if _, ok := pos[page]; ok {
+			return nil, &DuplicatePageError{page}
+		}
+		pos[page] = idx
+	}
+	return pos, nil
+}
+
+// Page ordering rules as a graph, an edge from a to b for every a|b rule
+type RuleSet struct {
+	graph map[int][]int
+}
+
+func NewRuleSet(graph map[int][]int) RuleSet {
+	return RuleSet{graph}
+}
+
+// Checks the update in O(n + rules between its pages), returns the first broken rule if any.
+// Fails on updates that repeat a page.
+func (r RuleSet) Validate(update []int) (Rule, bool, error) {
+	pos, err := pagePositions(update)
+	if err != nil {
+		return Rule{}, false, err
+	}
+
+	for idx, page := range update {
+		for _, after := range r.graph[page] {
+			if jdx, ok := pos[after]; ok && jdx < idx {
+				return Rule{page, after}, false, nil
+			}
+		}
+	}
+	return Rule{}, true, nil
+}
+
+// A cycle among the rules whose pages are all in the given set (every page when pages is nil),
+// nil if there is none. The cycle is returned as the pages on it, in order, without repeating the first one.
+func (r RuleSet) FindCycle(pages []int) []int {
+	inSet := func(int) bool { return true }
+	nodes := []int{}
+	if pages == nil {
+		for node := range r.graph {
+			nodes = append(nodes, node)
+		}
+		slices.Sort(nodes)
+	} else {
+		allowed := map[int]bool{}
+		for _, page := range pages {
+			allowed[page] = true
+		}
+		inSet = func(page int) bool { return allowed[page] }
+		nodes = pages
+	}
+
+	// 0 not visited, 1 on the current DFS path, 2 done
+	color := map[int]int{}
+	path := []int{}
+
+	var dfs func(node int) []int
+	dfs = func(node int) []int {
+		color[node] = 1
+		path = append(path, node)
+
+		for _, adj := range r.graph[node] {
+			if !inSet(adj) {
+				continue
+			}
+			if color[adj] == 1 {
+				// the cycle is the part of the path starting at adj
+				start := slices.Index(path, adj)
+				return slices.Clone(path[start:])
+			}
+			if color[adj] == 0 {
+				if cycle := dfs(adj); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		color[node] = 2
+		path = path[:len(path)-1]
+		return nil
+	}
+
+	for _, node := range nodes {
+		if color[node] == 0 {
+			if cycle := dfs(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Reorders the update with a topological sort of the rules restricted to its pages.
+// Pages ready at the same time are placed in the order they became ready, so pages that
+// no rule mentions can still move relative to the others.
+func (r RuleSet) Fix(update []int) ([]int, error) {
+	pos, err := pagePositions(update)
+	if err != nil {
+		return nil, err
+	}
+	inUpdate := func(page int) bool {
+		_, ok := pos[page]
+		return ok
+	}
+
+	deg := make(map[int]int, len(update))
+	for _, page := range update {
+		for _, after := range r.graph[page] {
+			if inUpdate(after) {
+				deg[after] += 1
+			}
+		}
+	}
+
+	queue := datastructures.Queue{}
+	for _, page := range update {
+		if deg[page] == 0 {
+			queue.Enqueue(page)
+		}
+	}
+
+	fixed := []int{}
+	for !queue.IsEmpty() {
+		page := queue.Dequeue().(int)
+		fixed = append(fixed, page)
+		for _, after := range r.graph[page] {
+			if !inUpdate(after) {
+				continue
+			}
+			deg[after] -= 1
+			if deg[after] == 0 {
+				queue.Enqueue(after)
+			}
+		}
+	}
+
+	if len(fixed) != len(update) {
+		return nil, &CycleError{r.FindCycle(update)}
+	}
+	return fixed, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const SAMPLE = `47|53
+97|13
+97|61
+97|47
+75|29
+61|13
+75|53
+29|13
+97|29
+53|29
+61|53
+97|53
+61|29
+47|13
+75|47
+97|75
+47|61
+75|61
+47|29
+75|13
+53|13
+
+75,47,61,53,29
+97,61,53,29,13
+75,29,13
+75,97,47,61,53
+61,13,29
+97,13,75,29,47`
+
+func sampleRules() (RuleSet, [][]int) {
+	graph, updates := makeGraphAndUpdates(strings.Split(SAMPLE, "\n"))
+	return NewRuleSet(graph), updates
+}
+
+func TestValidate(t *testing.T) {
+	rules, updates := sampleRules()
+
+	rule, ok, err := rules.Validate(updates[3])
+	if err != nil || ok || rule != (Rule{97, 75}) {
+		t.Fatalf("Expected update 4 to break rule 97|75, got %s (%v, %v) instead", rule, ok, err)
+	}
+
+	if _, ok, err := rules.Validate(updates[0]); err != nil || !ok {
+		t.Fatalf("Expected update 1 to be valid, got (%v, %v) instead", ok, err)
+	}
+
+	_, _, err = NewRuleSet(map[int][]int{1: {2}}).Validate([]int{2, 1, 2})
+	var dupErr *DuplicatePageError
+	if !errors.As(err, &dupErr) || dupErr.Page != 2 {
+		t.Fatalf("Expected a duplicate page error for page 2, got %v instead", err)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	rules := NewRuleSet(map[int][]int{1: {2}, 2: {3}, 3: {1}, 4: {1}})
+
+	cycle := rules.FindCycle(nil)
+	if !slices.Equal(cycle, []int{1, 2, 3}) {
+		t.Fatalf("Expected cycle [1 2 3], found %v instead", cycle)
+	}
+
+	if cycle := rules.FindCycle([]int{1, 2, 4}); cycle != nil {
+		t.Fatalf("Expected no cycle among 1, 2 and 4, found %v instead", cycle)
+	}
+
+	_, err := rules.Fix([]int{3, 2, 1})
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) || len(cycleErr.Cycle) != 3 {
+		t.Fatalf("Expected fixing 3, 2, 1 to fail with a 3 page cycle, got %v instead", err)
+	}
+}
+
+func TestFixSample(t *testing.T) {
+	rules, updates := sampleRules()
+
+	if res := solvePartOne(rules, updates); res != 143 {
+		t.Fatalf("Expected part one to be 143, it was %d instead", res)
+	}
+
+	if res := solvePartTwo(rules, updates, rules.Fix); res != 123 {
+		t.Fatalf("Expected part two to be 123, it was %d instead", res)
+	}
+
+	fixed, err := rules.Fix(updates[5])
+	if err != nil || !slices.Equal(fixed, []int{97, 75, 47, 29, 13}) {
+		t.Fatalf("Expected update 6 to be fixed as [97 75 47 29 13], got %v (%v) instead", fixed, err)
+	}
+}
@@ -0,0 +1,65 @@
+package combinatorics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerators(t *testing.T) {
+	count := 0
+	for c := range Combinations(5, 2) {
+		if c[0] >= c[1] {
+			t.Fatalf("Expected combination indices to be increasing, found %v", c)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Fatalf("Expected 10 combinations, found %d instead", count)
+	}
+
+	last := []int{}
+	for p := range Permutations(4) {
+		last = slices.Clone(p)
+	}
+	if !slices.Equal(last, []int{3, 2, 1, 0}) {
+		t.Fatalf("Expected last permutation to be [3 2 1 0], it was %v instead", last)
+	}
+
+	products := [][]int{}
+	for digits := range Product([]int{2, 3}) {
+		products = append(products, slices.Clone(digits))
+	}
+	if len(products) != 6 || !slices.Equal(products[1], []int{0, 1}) || !slices.Equal(products[5], []int{1, 2}) {
+		t.Fatalf("Expected 6 products with the last digit changing fastest, found %v instead", products)
+	}
+
+	words := []string{}
+	for p := range ProductOf([][]string{{"<", ">"}, {"A"}, {"^", "v"}}) {
+		words = append(words, p[0]+p[1]+p[2])
+	}
+	if !slices.Equal(words, []string{"<A^", "<Av", ">A^", ">Av"}) {
+		t.Fatalf("Expected [<A^ <Av >A^ >Av], found %v instead", words)
+	}
+}
+
+func TestCounting(t *testing.T) {
+	if r, ok := Binomial(10, 3); !ok || r != 120 {
+		t.Fatalf("Expected 10 choose 3 to be 120, it was %d instead", r)
+	}
+
+	if r, ok := Binomial(66, 33); !ok || r != 7219428434016265740 {
+		t.Fatalf("Expected 66 choose 33 to be 7219428434016265740, it was %d instead", r)
+	}
+
+	if _, ok := Binomial(68, 34); ok {
+		t.Fatalf("Expected 68 choose 34 to overflow")
+	}
+
+	if _, ok := Factorial(21); ok {
+		t.Fatalf("Expected 21! to overflow")
+	}
+
+	if r, ok := ProductSize([]int{3, 3, 3}); !ok || r != 27 {
+		t.Fatalf("Expected 27 products, found %d instead", r)
+	}
+}
@@ -0,0 +1,49 @@
+package combinatorics
+
+import "aoc-2024/datastructures"
+
+// n choose k, false if the result overflows an int
+func Binomial(n int, k int) (int, bool) {
+	if k < 0 || k > n {
+		return 0, true
+	}
+	k = min(k, n-k)
+
+	res := 1
+	for idx := 1; idx <= k; idx++ {
+		// res * (n - k + idx) / idx is always an integer, divide by the gcd first to stay small
+		g := datastructures.GCD(res, idx)
+		var ok bool
+		res, ok = datastructures.MulChecked(res/g, (n-k+idx)/(idx/g))
+		if !ok {
+			return 0, false
+		}
+	}
+	return res, true
+}
+
+// n!, false if the result overflows an int
+func Factorial(n int) (int, bool) {
+	res := 1
+	for idx := 2; idx <= n; idx++ {
+		var ok bool
+		res, ok = datastructures.MulChecked(res, idx)
+		if !ok {
+			return 0, false
+		}
+	}
+	return res, true
+}
+
+// Number of values Product(radix) goes through, false if it overflows an int
+func ProductSize(radix []int) (int, bool) {
+	res := 1
+	for _, r := range radix {
+		var ok bool
+		res, ok = datastructures.MulChecked(res, max(r, 0))
+		if !ok {
+			return 0, false
+		}
+	}
+	return res, true
+}
@@ -0,0 +1,143 @@
+package combinatorics
+
+import "iter"
+
+// The generators below yield index slices that are reused between iterations,
+// clone them before keeping them around or modifying them.
+
+// Every k element subset of [0, n), in lexicographic order
+func Combinations(n int, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k < 0 || k > n {
+			return
+		}
+
+		indices := make([]int, k)
+		for idx := range indices {
+			indices[idx] = idx
+		}
+
+		for {
+			if !yield(indices) {
+				return
+			}
+
+			// find the rightmost index that can still move to the right
+			pos := k - 1
+			for pos >= 0 && indices[pos] == n-k+pos {
+				pos--
+			}
+			if pos < 0 {
+				return
+			}
+
+			indices[pos]++
+			for idx := pos + 1; idx < k; idx++ {
+				indices[idx] = indices[idx-1] + 1
+			}
+		}
+	}
+}
+
+// Every ordering of [0, n), in lexicographic order
+func Permutations(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if n < 0 {
+			return
+		}
+
+		indices := make([]int, n)
+		for idx := range indices {
+			indices[idx] = idx
+		}
+
+		for {
+			if !yield(indices) {
+				return
+			}
+			if !nextPermutation(indices) {
+				return
+			}
+		}
+	}
+}
+
+func nextPermutation(indices []int) bool {
+	pivot := len(indices) - 2
+	for pivot >= 0 && indices[pivot] >= indices[pivot+1] {
+		pivot--
+	}
+	if pivot < 0 {
+		return false
+	}
+
+	swap := len(indices) - 1
+	for indices[swap] <= indices[pivot] {
+		swap--
+	}
+	indices[pivot], indices[swap] = indices[swap], indices[pivot]
+
+	for l, r := pivot+1, len(indices)-1; l < r; l, r = l+1, r-1 {
+		indices[l], indices[r] = indices[r], indices[l]
+	}
+	return true
+}
+
+// Counts in a mixed radix: digit i goes from 0 to radix[i] - 1, the last digit changes the fastest.
+// Product([]int{2, 2, 2}) is every 3 bit number, Product([]int{3, 3}) every pair of operators out of 3.
+func Product(radix []int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, r := range radix {
+			if r <= 0 {
+				return
+			}
+		}
+
+		digits := make([]int, len(radix))
+		for {
+			if !yield(digits) {
+				return
+			}
+
+			pos := len(digits) - 1
+			for pos >= 0 && digits[pos] == radix[pos]-1 {
+				digits[pos] = 0
+				pos--
+			}
+			if pos < 0 {
+				return
+			}
+			digits[pos]++
+		}
+	}
+}
+
+// Same as Product([]int{base, base, ...}) with n digits
+func Repeat(base int, n int) iter.Seq[[]int] {
+	radix := make([]int, n)
+	for idx := range radix {
+		radix[idx] = base
+	}
+	return Product(radix)
+}
+
+// Cartesian product of the choices, picking one element out of every slice.
+// Each yielded slice is freshly allocated.
+func ProductOf[T any](choices [][]T) iter.Seq[[]T] {
+	radix := make([]int, len(choices))
+	for idx, choice := range choices {
+		radix[idx] = len(choice)
+	}
+
+	return func(yield func([]T) bool) {
+		for digits := range Product(radix) {
+			res := make([]T, len(digits))
+			for idx, digit := range digits {
+				res[idx] = choices[idx][digit]
+			}
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package cycle
+
+import "fmt"
+
+// All the finders work on sequences x0, f(x0), f(f(x0)), ... that eventually repeat.
+// They return mu, the index of the first state on the cycle, and lambda, the length of the cycle,
+// so that state i == state i + lambda for every i >= mu.
+
+// Floyd's tortoise and hare, constant memory
+func Floyd[S comparable](x0 S, f func(S) S) (int, int) {
+	tortoise := f(x0)
+	hare := f(f(x0))
+	for tortoise != hare {
+		tortoise = f(tortoise)
+		hare = f(f(hare))
+	}
+
+	mu := 0
+	tortoise = x0
+	for tortoise != hare {
+		tortoise = f(tortoise)
+		hare = f(hare)
+		mu++
+	}
+
+	lambda := 1
+	hare = f(tortoise)
+	for tortoise != hare {
+		hare = f(hare)
+		lambda++
+	}
+
+	return mu, lambda
+}
+
+// Brent's algorithm, constant memory and usually fewer calls to f than Floyd
+func Brent[S comparable](x0 S, f func(S) S) (int, int) {
+	power := 1
+	lambda := 1
+	tortoise := x0
+	hare := f(x0)
+	for tortoise != hare {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = f(hare)
+		lambda++
+	}
+
+	tortoise = x0
+	hare = x0
+	for idx := 0; idx < lambda; idx++ {
+		hare = f(hare)
+	}
+
+	mu := 0
+	for tortoise != hare {
+		tortoise = f(tortoise)
+		hare = f(hare)
+		mu++
+	}
+
+	return mu, lambda
+}
+
+// Remembers the key of every state seen so far, for states that aren't comparable themselves
+// (grids, slices) or when f is expensive enough that calling it only mu + lambda times matters.
+func Find[S any, K comparable](x0 S, f func(S) S, key func(S) K) (int, int) {
+	mu, lambda, _ := find(x0, f, key, -1)
+	return mu, lambda
+}
+
+// Returns the state after n applications of f, skipping over the full periods once the cycle is found
+func StateAt[S any, K comparable](x0 S, f func(S) S, key func(S) K, n int) S {
+	if n < 0 {
+		panic(fmt.Sprintf("This function should only be called with a non negative number of steps, found %d", n))
+	}
+
+	mu, lambda, states := find(x0, f, key, n)
+	if n < len(states) {
+		return states[n]
+	}
+	return states[mu+(n-mu)%lambda]
+}
+
+// Stops early if the n-th state is reached before the cycle closes, mu and lambda are 0 in that case
+func find[S any, K comparable](x0 S, f func(S) S, key func(S) K, n int) (int, int, []S) {
+	seen := map[K]int{}
+	states := []S{}
+
+	state := x0
+	for idx := 0; ; idx++ {
+		k := key(state)
+		if first, ok := seen[k]; ok {
+			return first, idx - first, states
+		}
+		seen[k] = idx
+		states = append(states, state)
+		if idx == n {
+			return 0, 0, states
+		}
+		state = f(state)
+	}
+}
@@ -0,0 +1,55 @@
+package cycle
+
+import (
+	"testing"
+)
+
+// 0, 1, 2, 3, 4, 5, 3, 4, 5, ...
+func step(x int) int {
+	if x == 5 {
+		return 3
+	}
+	return x + 1
+}
+
+func identity(x int) int {
+	return x
+}
+
+func TestFinders(t *testing.T) {
+	mu, lambda := Floyd(0, step)
+	if mu != 3 || lambda != 3 {
+		t.Fatalf("Expected Floyd to find mu = 3 and lambda = 3, found %d and %d instead", mu, lambda)
+	}
+
+	mu, lambda = Brent(0, step)
+	if mu != 3 || lambda != 3 {
+		t.Fatalf("Expected Brent to find mu = 3 and lambda = 3, found %d and %d instead", mu, lambda)
+	}
+
+	mu, lambda = Find(0, step, identity)
+	if mu != 3 || lambda != 3 {
+		t.Fatalf("Expected Find to find mu = 3 and lambda = 3, found %d and %d instead", mu, lambda)
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	r := StateAt(0, step, identity, 2)
+	if r != 2 {
+		t.Fatalf("Expected state 2 to be 2, it was %d instead", r)
+	}
+
+	r = StateAt(0, step, identity, 1_000_000_000_000)
+	if r != 4 {
+		t.Fatalf("Expected state 1e12 to be 4, it was %d instead", r)
+	}
+}
+
+func TestStateAtNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected a negative number of steps to panic")
+		}
+	}()
+	StateAt(0, step, identity, -1)
+}
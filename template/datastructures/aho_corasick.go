@@ -0,0 +1,110 @@
+package datastructures
+
+type acNode struct {
+	children map[byte]int
+	fail     int
+	// closest node on the fail chain that ends a pattern, -1 if none
+	dictLink int
+	patterns []int
+}
+
+type Match struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// Aho-Corasick automaton, finds every occurrence of every pattern in a single pass over the text
+type AhoCorasick struct {
+	patterns []string
+	nodes    []acNode
+}
+
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	ac := &AhoCorasick{patterns: patterns, nodes: []acNode{{children: map[byte]int{}, dictLink: -1}}}
+
+	for patternIdx, pattern := range patterns {
+		node := 0
+		for idx := 0; idx < len(pattern); idx++ {
+			next, ok := ac.nodes[node].children[pattern[idx]]
+			if !ok {
+				next = len(ac.nodes)
+				ac.nodes = append(ac.nodes, acNode{children: map[byte]int{}, dictLink: -1})
+				ac.nodes[node].children[pattern[idx]] = next
+			}
+			node = next
+		}
+		ac.nodes[node].patterns = append(ac.nodes[node].patterns, patternIdx)
+	}
+
+	// fail links by BFS, a node's fail link is always shallower than the node itself
+	queue := Queue{}
+	for _, child := range ac.nodes[0].children {
+		queue.Enqueue(child)
+	}
+
+	for !queue.IsEmpty() {
+		node := queue.Dequeue().(int)
+		for chr, child := range ac.nodes[node].children {
+			fail := ac.nodes[node].fail
+			for fail > 0 && !ac.hasChild(fail, chr) {
+				fail = ac.nodes[fail].fail
+			}
+			if next, ok := ac.nodes[fail].children[chr]; ok && next != child {
+				fail = next
+			}
+			ac.nodes[child].fail = fail
+
+			if len(ac.nodes[fail].patterns) > 0 {
+				ac.nodes[child].dictLink = fail
+			} else {
+				ac.nodes[child].dictLink = ac.nodes[fail].dictLink
+			}
+			queue.Enqueue(child)
+		}
+	}
+
+	return ac
+}
+
+func (ac *AhoCorasick) hasChild(node int, chr byte) bool {
+	_, ok := ac.nodes[node].children[chr]
+	return ok
+}
+
+func (ac *AhoCorasick) step(node int, chr byte) int {
+	for {
+		if next, ok := ac.nodes[node].children[chr]; ok {
+			return next
+		}
+		if node == 0 {
+			return 0
+		}
+		node = ac.nodes[node].fail
+	}
+}
+
+// Calls visit for every occurrence, ordered by end position. Stops early if visit returns false.
+func (ac *AhoCorasick) Scan(text string, visit func(Match) bool) {
+	node := 0
+	for idx := 0; idx < len(text); idx++ {
+		node = ac.step(node, text[idx])
+		for out := node; out > 0; out = ac.nodes[out].dictLink {
+			for _, patternIdx := range ac.nodes[out].patterns {
+				start := idx + 1 - len(ac.patterns[patternIdx])
+				if !visit(Match{patternIdx, start, idx + 1}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (ac *AhoCorasick) FindAll(text string) []Match {
+	res := []Match{}
+	ac.Scan(text, func(m Match) bool {
+		res = append(res, m)
+		return true
+	})
+	return res
+}
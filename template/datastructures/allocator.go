@@ -0,0 +1,146 @@
+package datastructures
+
+import "fmt"
+
+type allocatorNode struct {
+	// longest free run starting at the left edge, ending at the right edge, and anywhere
+	prefix int
+	suffix int
+	best   int
+	size   int
+	// -1 nothing pending, 0 whole range used, 1 whole range free
+	lazy int
+}
+
+// First fit allocator over the positions [0, size). Finding the leftmost free run of a given
+// length, reserving and freeing ranges are all O(log size), it's a segment tree over the gaps.
+type FirstFitAllocator struct {
+	size  int
+	nodes []allocatorNode
+}
+
+// All positions start out free
+func NewFirstFitAllocator(size int) *FirstFitAllocator {
+	a := &FirstFitAllocator{size, make([]allocatorNode, 4*max(size, 1))}
+	a.build(1, 0, size-1)
+	return a
+}
+
+func (a *FirstFitAllocator) build(node int, lo int, hi int) {
+	a.nodes[node] = allocatorNode{prefix: hi - lo + 1, suffix: hi - lo + 1, best: hi - lo + 1, size: hi - lo + 1, lazy: -1}
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	a.build(2*node, lo, mid)
+	a.build(2*node+1, mid+1, hi)
+}
+
+func (a *FirstFitAllocator) apply(node int, free int) {
+	n := &a.nodes[node]
+	run := n.size * free
+	n.prefix, n.suffix, n.best = run, run, run
+	n.lazy = free
+}
+
+func (a *FirstFitAllocator) push(node int) {
+	if a.nodes[node].lazy >= 0 {
+		a.apply(2*node, a.nodes[node].lazy)
+		a.apply(2*node+1, a.nodes[node].lazy)
+		a.nodes[node].lazy = -1
+	}
+}
+
+func (a *FirstFitAllocator) pull(node int) {
+	left, right := a.nodes[2*node], a.nodes[2*node+1]
+	n := &a.nodes[node]
+
+	n.prefix = left.prefix
+	if left.prefix == left.size {
+		n.prefix += right.prefix
+	}
+	n.suffix = right.suffix
+	if right.suffix == right.size {
+		n.suffix += left.suffix
+	}
+	n.best = max(left.best, right.best, left.suffix+right.prefix)
+}
+
+func (a *FirstFitAllocator) update(node int, lo int, hi int, from int, to int, free int) {
+	if to < lo || hi < from {
+		return
+	}
+	if from <= lo && hi <= to {
+		a.apply(node, free)
+		return
+	}
+	a.push(node)
+	mid := (lo + hi) / 2
+	a.update(2*node, lo, mid, from, to, free)
+	a.update(2*node+1, mid+1, hi, from, to, free)
+	a.pull(node)
+}
+
+func (a *FirstFitAllocator) find(node int, lo int, hi int, length int) int {
+	if lo == hi {
+		return lo
+	}
+	a.push(node)
+	mid := (lo + hi) / 2
+	left, right := a.nodes[2*node], a.nodes[2*node+1]
+
+	if left.best >= length {
+		return a.find(2*node, lo, mid, length)
+	}
+	if left.suffix+right.prefix >= length {
+		return mid - left.suffix + 1
+	}
+	return a.find(2*node+1, mid+1, hi, length)
+}
+
+func (a *FirstFitAllocator) checkRange(start int, length int) {
+	if start < 0 || length < 0 || start+length > a.size {
+		panic(fmt.Sprintf("Range [%d, %d) is outside of the allocator [0, %d)", start, start+length, a.size))
+	}
+}
+
+// Start of the leftmost free run of at least length positions, false if there is none
+func (a *FirstFitAllocator) FirstFit(length int) (int, bool) {
+	if length <= 0 || a.size == 0 || a.nodes[1].best < length {
+		return -1, false
+	}
+	return a.find(1, 0, a.size-1, length), true
+}
+
+// Finds the leftmost fit and marks it as used
+func (a *FirstFitAllocator) Allocate(length int) (int, bool) {
+	start, ok := a.FirstFit(length)
+	if ok {
+		a.Reserve(start, length)
+	}
+	return start, ok
+}
+
+// Marks [start, start + length) as used, whether it was free or not
+func (a *FirstFitAllocator) Reserve(start int, length int) {
+	a.checkRange(start, length)
+	if length > 0 {
+		a.update(1, 0, a.size-1, start, start+length-1, 0)
+	}
+}
+
+// Marks [start, start + length) as free
+func (a *FirstFitAllocator) Free(start int, length int) {
+	a.checkRange(start, length)
+	if length > 0 {
+		a.update(1, 0, a.size-1, start, start+length-1, 1)
+	}
+}
+
+// Length of the longest free run
+func (a *FirstFitAllocator) LargestFree() int {
+	if a.size == 0 {
+		return 0
+	}
+	return a.nodes[1].best
+}
@@ -0,0 +1,143 @@
+package datastructures
+
+import "math/bits"
+
+// Dense set of non negative integers, grows as needed when bits are set
+type Bitset []uint64
+
+func NewBitset(size int) Bitset {
+	return make(Bitset, (size+63)/64)
+}
+
+func (b *Bitset) Set(idx int) {
+	word := idx / 64
+	if word >= len(*b) {
+		*b = append(*b, make(Bitset, word-len(*b)+1)...)
+	}
+	(*b)[word] |= 1 << (idx % 64)
+}
+
+func (b Bitset) Clear(idx int) {
+	if idx/64 < len(b) {
+		b[idx/64] &^= 1 << (idx % 64)
+	}
+}
+
+func (b Bitset) Test(idx int) bool {
+	return idx/64 < len(b) && b[idx/64]&(1<<(idx%64)) != 0
+}
+
+func (b Bitset) Clone() Bitset {
+	return append(Bitset{}, b...)
+}
+
+func (b Bitset) And(other Bitset) Bitset {
+	res := make(Bitset, min(len(b), len(other)))
+	for idx := range res {
+		res[idx] = b[idx] & other[idx]
+	}
+	return res
+}
+
+func (b Bitset) Or(other Bitset) Bitset {
+	if len(b) < len(other) {
+		b, other = other, b
+	}
+	res := b.Clone()
+	for idx, word := range other {
+		res[idx] |= word
+	}
+	return res
+}
+
+// Elements of b that are not in other
+func (b Bitset) AndNot(other Bitset) Bitset {
+	res := b.Clone()
+	for idx := 0; idx < min(len(b), len(other)); idx++ {
+		res[idx] &^= other[idx]
+	}
+	return res
+}
+
+func (b Bitset) PopCount() int {
+	count := 0
+	for _, word := range b {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+func (b Bitset) IsEmpty() bool {
+	for _, word := range b {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// First set bit at a position >= from, false if there is none.
+//
+//	for idx, ok := b.NextSet(0); ok; idx, ok = b.NextSet(idx + 1) { ... }
+func (b Bitset) NextSet(from int) (int, bool) {
+	word := from / 64
+	if from < 0 || word >= len(b) {
+		return -1, false
+	}
+
+	// drop the bits before from in the first word
+	w := b[word] >> (from % 64)
+	if w != 0 {
+		return from + bits.TrailingZeros64(w), true
+	}
+
+	for word++; word < len(b); word++ {
+		if b[word] != 0 {
+			return word*64 + bits.TrailingZeros64(b[word]), true
+		}
+	}
+	return -1, false
+}
+
+// Fixed width variant for sets of at most 64 elements, it's a plain value so it can be
+// copied around and used as a map key for free
+type Bitset64 uint64
+
+func (b Bitset64) Set(idx int) Bitset64 {
+	return b | 1<<idx
+}
+
+func (b Bitset64) Clear(idx int) Bitset64 {
+	return b &^ (1 << idx)
+}
+
+func (b Bitset64) Test(idx int) bool {
+	return b&(1<<idx) != 0
+}
+
+func (b Bitset64) And(other Bitset64) Bitset64 {
+	return b & other
+}
+
+func (b Bitset64) Or(other Bitset64) Bitset64 {
+	return b | other
+}
+
+func (b Bitset64) AndNot(other Bitset64) Bitset64 {
+	return b &^ other
+}
+
+func (b Bitset64) PopCount() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+func (b Bitset64) NextSet(from int) (int, bool) {
+	if from < 0 || from >= 64 {
+		return -1, false
+	}
+	w := uint64(b) >> from
+	if w == 0 {
+		return -1, false
+	}
+	return from + bits.TrailingZeros64(w), true
+}
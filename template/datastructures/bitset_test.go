@@ -0,0 +1,56 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	a := NewBitset(10)
+	for _, idx := range []int{1, 5, 64, 130} {
+		a.Set(idx)
+	}
+
+	b := Bitset{}
+	b.Set(5)
+	b.Set(130)
+	b.Set(200)
+
+	if !a.Test(64) || a.Test(63) || a.Test(1000) {
+		t.Fatalf("Expected only 64 to be set out of 63, 64 and 1000")
+	}
+
+	if r := a.And(b).PopCount(); r != 2 {
+		t.Fatalf("Expected intersection to have 2 elements, it had %d instead", r)
+	}
+
+	if r := a.Or(b).PopCount(); r != 5 {
+		t.Fatalf("Expected union to have 5 elements, it had %d instead", r)
+	}
+
+	found := []int{}
+	diff := a.AndNot(b)
+	for idx, ok := diff.NextSet(0); ok; idx, ok = diff.NextSet(idx + 1) {
+		found = append(found, idx)
+	}
+	if !slices.Equal(found, []int{1, 64}) {
+		t.Fatalf("Expected difference to be [1 64], it was %v instead", found)
+	}
+}
+
+func TestBitset64(t *testing.T) {
+	lock := Bitset64(0).Set(0).Set(3).Set(63)
+	key := Bitset64(0).Set(1).Set(2)
+
+	if lock.And(key) != 0 {
+		t.Fatalf("Expected lock and key to not overlap")
+	}
+
+	if idx, ok := lock.NextSet(4); !ok || idx != 63 {
+		t.Fatalf("Expected next set bit after 4 to be 63, it was %d instead", idx)
+	}
+
+	if lock.Clear(63).PopCount() != 2 {
+		t.Fatalf("Expected 2 bits after clearing 63")
+	}
+}
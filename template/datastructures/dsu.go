@@ -0,0 +1,95 @@
+package datastructures
+
+// Disjoint set union with path compression and union by rank.
+// Elements are added lazily the first time they are seen by any of the methods.
+type DSU[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	size   map[T]int
+	count  int
+}
+
+func NewDSU[T comparable]() *DSU[T] {
+	return &DSU[T]{
+		parent: map[T]T{},
+		rank:   map[T]int{},
+		size:   map[T]int{},
+	}
+}
+
+func (d *DSU[T]) Add(x T) {
+	if _, ok := d.parent[x]; ok {
+		return
+	}
+	d.parent[x] = x
+	d.rank[x] = 0
+	d.size[x] = 1
+	d.count++
+}
+
+func (d *DSU[T]) Find(x T) T {
+	d.Add(x)
+
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	// compress the path so that every node on it points straight to the root
+	for x != root {
+		next := d.parent[x]
+		d.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Returns false if x and y were already in the same set
+func (d *DSU[T]) Union(x T, y T) bool {
+	rootX := d.Find(x)
+	rootY := d.Find(y)
+
+	if rootX == rootY {
+		return false
+	}
+
+	if d.rank[rootX] < d.rank[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+
+	if d.rank[rootX] == d.rank[rootY] {
+		d.rank[rootX]++
+	}
+
+	d.parent[rootY] = rootX
+	d.size[rootX] += d.size[rootY]
+	delete(d.size, rootY)
+	delete(d.rank, rootY)
+
+	d.count--
+	return true
+}
+
+func (d *DSU[T]) Connected(x T, y T) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Size of the set containing x
+func (d *DSU[T]) Size(x T) int {
+	return d.size[d.Find(x)]
+}
+
+// Number of disjoint sets
+func (d *DSU[T]) Count() int {
+	return d.count
+}
+
+// Groups all the elements by their set, keyed by the set representative
+func (d *DSU[T]) Components() map[T][]T {
+	res := map[T][]T{}
+	for x := range d.parent {
+		root := d.Find(x)
+		res[root] = append(res[root], x)
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package datastructures
+
+import (
+	"testing"
+)
+
+func TestDSU(t *testing.T) {
+	dsu := NewDSU[string]()
+
+	dsu.Union("a", "b")
+	dsu.Union("c", "d")
+	dsu.Union("b", "d")
+	dsu.Add("e")
+
+	if !dsu.Connected("a", "c") {
+		t.Fatalf("Expected a and c to be connected")
+	}
+
+	if dsu.Connected("a", "e") {
+		t.Fatalf("Expected a and e to not be connected")
+	}
+
+	if dsu.Union("a", "d") {
+		t.Fatalf("Expected union of a and d to be a no-op")
+	}
+
+	if dsu.Size("c") != 4 {
+		t.Fatalf("Expected the set of c to have 4 elements, it had %d instead", dsu.Size("c"))
+	}
+
+	components := dsu.Components()
+	if len(components) != 2 || dsu.Count() != 2 {
+		t.Fatalf("Expected 2 components, found %d instead", len(components))
+	}
+}
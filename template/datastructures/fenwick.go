@@ -0,0 +1,49 @@
+package datastructures
+
+import "golang.org/x/exp/constraints"
+
+// Binary indexed tree for point updates and prefix sums, both in O(log n)
+type Fenwick[T constraints.Integer | constraints.Float] struct {
+	tree []T
+}
+
+func NewFenwick[T constraints.Integer | constraints.Float](n int) *Fenwick[T] {
+	return &Fenwick[T]{make([]T, n+1)}
+}
+
+func NewFenwickFromArray[T constraints.Integer | constraints.Float](values []T) *Fenwick[T] {
+	f := NewFenwick[T](len(values))
+	copy(f.tree[1:], values)
+	// push every node into its parent, O(n) instead of n calls to Add
+	for idx := 1; idx < len(f.tree); idx++ {
+		parent := idx + (idx & -idx)
+		if parent < len(f.tree) {
+			f.tree[parent] += f.tree[idx]
+		}
+	}
+	return f
+}
+
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+func (f *Fenwick[T]) Add(idx int, delta T) {
+	for idx++; idx < len(f.tree); idx += idx & -idx {
+		f.tree[idx] += delta
+	}
+}
+
+// Sum of the values in [0, idx)
+func (f *Fenwick[T]) PrefixSum(idx int) T {
+	var sum T
+	for ; idx > 0; idx -= idx & -idx {
+		sum += f.tree[idx]
+	}
+	return sum
+}
+
+// Sum of the values in [l, r)
+func (f *Fenwick[T]) RangeSum(l int, r int) T {
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
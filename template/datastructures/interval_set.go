@@ -0,0 +1,107 @@
+package datastructures
+
+import "sort"
+
+// Half open interval [Start, End)
+type Interval struct {
+	Start int
+	End   int
+}
+
+func (i Interval) Len() int {
+	return i.End - i.Start
+}
+
+func (i Interval) Overlaps(other Interval) bool {
+	return i.Start < other.End && other.Start < i.End
+}
+
+// Set of integers stored as sorted, disjoint and non adjacent intervals
+type IntervalSet struct {
+	intervals []Interval
+}
+
+func NewIntervalSet() *IntervalSet {
+	return &IntervalSet{}
+}
+
+// Index of the first interval that ends at or after x
+func (s *IntervalSet) search(x int) int {
+	return sort.Search(len(s.intervals), func(idx int) bool {
+		return s.intervals[idx].End >= x
+	})
+}
+
+// Adds [start, end), merging it with every interval it overlaps or touches
+func (s *IntervalSet) Insert(start int, end int) {
+	if start >= end {
+		return
+	}
+
+	lo := s.search(start)
+	hi := lo
+	for hi < len(s.intervals) && s.intervals[hi].Start <= end {
+		start = min(start, s.intervals[hi].Start)
+		end = max(end, s.intervals[hi].End)
+		hi++
+	}
+
+	s.intervals = append(s.intervals[:lo], append([]Interval{{start, end}}, s.intervals[hi:]...)...)
+}
+
+// Removes [start, end) from the set, splitting the intervals that stick out on either side
+func (s *IntervalSet) Subtract(start int, end int) {
+	if start >= end {
+		return
+	}
+
+	lo := s.search(start + 1)
+	hi := lo
+	kept := []Interval{}
+	for hi < len(s.intervals) && s.intervals[hi].Start < end {
+		curr := s.intervals[hi]
+		if curr.Start < start {
+			kept = append(kept, Interval{curr.Start, start})
+		}
+		if curr.End > end {
+			kept = append(kept, Interval{end, curr.End})
+		}
+		hi++
+	}
+
+	s.intervals = append(s.intervals[:lo], append(kept, s.intervals[hi:]...)...)
+}
+
+func (s *IntervalSet) Contains(x int) bool {
+	idx := s.search(x + 1)
+	return idx < len(s.intervals) && s.intervals[idx].Start <= x
+}
+
+// The parts of the set that fall inside [start, end)
+func (s *IntervalSet) Overlapping(start int, end int) []Interval {
+	res := []Interval{}
+	for idx := s.search(start + 1); idx < len(s.intervals) && s.intervals[idx].Start < end; idx++ {
+		res = append(res, Interval{max(start, s.intervals[idx].Start), min(end, s.intervals[idx].End)})
+	}
+	return res
+}
+
+// Merges every interval of other into s
+func (s *IntervalSet) Union(other *IntervalSet) {
+	for _, interval := range other.intervals {
+		s.Insert(interval.Start, interval.End)
+	}
+}
+
+func (s *IntervalSet) Intervals() []Interval {
+	return append([]Interval{}, s.intervals...)
+}
+
+// Number of integers in the set
+func (s *IntervalSet) Size() int {
+	size := 0
+	for _, interval := range s.intervals {
+		size += interval.Len()
+	}
+	return size
+}
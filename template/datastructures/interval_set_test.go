@@ -0,0 +1,71 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntervalSet(t *testing.T) {
+	s := NewIntervalSet()
+	s.Insert(0, 5)
+	s.Insert(10, 15)
+	s.Insert(5, 7)
+	s.Insert(20, 30)
+	s.Insert(12, 22)
+
+	expected := []Interval{{0, 7}, {10, 30}}
+	if !slices.Equal(s.Intervals(), expected) {
+		t.Fatalf("Expected %v after merging, found %v instead", expected, s.Intervals())
+	}
+
+	s.Subtract(3, 12)
+	expected = []Interval{{0, 3}, {12, 30}}
+	if !slices.Equal(s.Intervals(), expected) {
+		t.Fatalf("Expected %v after subtracting, found %v instead", expected, s.Intervals())
+	}
+
+	if s.Contains(3) || !s.Contains(2) || !s.Contains(12) || s.Contains(30) {
+		t.Fatalf("Expected only 2 and 12 to be in %v out of 2, 3, 12 and 30", s.Intervals())
+	}
+
+	overlap := s.Overlapping(1, 14)
+	if !slices.Equal(overlap, []Interval{{1, 3}, {12, 14}}) {
+		t.Fatalf("Expected overlap with [1, 14) to be [{1 3} {12 14}], found %v instead", overlap)
+	}
+
+	if s.Size() != 21 {
+		t.Fatalf("Expected set to have 21 elements, it had %d instead", s.Size())
+	}
+}
+
+func TestFirstFitAllocator(t *testing.T) {
+	// day 9 example: 00...111...2...333.44.5555.6666.777.888899
+	disk := "00...111...2...333.44.5555.6666.777.888899"
+	a := NewFirstFitAllocator(len(disk))
+	for idx, chr := range disk {
+		if chr != '.' {
+			a.Reserve(idx, 1)
+		}
+	}
+
+	if start, ok := a.FirstFit(3); !ok || start != 2 {
+		t.Fatalf("Expected first gap of 3 to start at 2, found %d instead", start)
+	}
+
+	if start, ok := a.Allocate(2); !ok || start != 2 {
+		t.Fatalf("Expected to allocate 2 at 2, found %d instead", start)
+	}
+
+	if start, ok := a.FirstFit(2); !ok || start != 8 {
+		t.Fatalf("Expected next gap of 2 to start at 8, found %d instead", start)
+	}
+
+	if _, ok := a.FirstFit(4); ok {
+		t.Fatalf("Expected no gap of 4")
+	}
+
+	a.Free(5, 3)
+	if start, ok := a.FirstFit(4); !ok || start != 4 {
+		t.Fatalf("Expected a gap of 4 at 4 after freeing [5, 8), found %d instead", start)
+	}
+}
@@ -0,0 +1,108 @@
+package datastructures
+
+// Describes how range updates of type U act on values of type T
+type LazyOps[T any, U any] struct {
+	// No-op update
+	Identity U
+	// Applies the update to the combined value of a range of the given length
+	Apply func(update U, value T, length int) T
+	// Update equivalent to applying older first and newer after it
+	Compose func(newer U, older U) U
+}
+
+// Range updates and range queries in O(log n), ranges are half open [l, r).
+//
+// Range add / range sum, for example:
+//
+//	NewLazySegmentTree(values, SumMonoid[int](), LazyOps[int, int]{
+//		0,
+//		func(u int, v int, length int) int { return v + u*length },
+//		func(newer int, older int) int { return newer + older },
+//	})
+type LazySegmentTree[T any, U any] struct {
+	n      int
+	monoid Monoid[T]
+	ops    LazyOps[T, U]
+	tree   []T
+	lazy   []U
+}
+
+func NewLazySegmentTree[T any, U any](values []T, monoid Monoid[T], ops LazyOps[T, U]) *LazySegmentTree[T, U] {
+	n := len(values)
+	s := &LazySegmentTree[T, U]{n, monoid, ops, make([]T, 4*max(n, 1)), make([]U, 4*max(n, 1))}
+	for idx := range s.lazy {
+		s.lazy[idx] = ops.Identity
+	}
+	if n > 0 {
+		s.build(values, 1, 0, n)
+	}
+	return s
+}
+
+func (s *LazySegmentTree[T, U]) Len() int {
+	return s.n
+}
+
+func (s *LazySegmentTree[T, U]) build(values []T, node int, lo int, hi int) {
+	if hi-lo == 1 {
+		s.tree[node] = values[lo]
+		return
+	}
+	mid := (lo + hi) / 2
+	s.build(values, 2*node, lo, mid)
+	s.build(values, 2*node+1, mid, hi)
+	s.tree[node] = s.monoid.Combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+func (s *LazySegmentTree[T, U]) applyTo(node int, length int, update U) {
+	s.tree[node] = s.ops.Apply(update, s.tree[node], length)
+	s.lazy[node] = s.ops.Compose(update, s.lazy[node])
+}
+
+func (s *LazySegmentTree[T, U]) push(node int, lo int, mid int, hi int) {
+	s.applyTo(2*node, mid-lo, s.lazy[node])
+	s.applyTo(2*node+1, hi-mid, s.lazy[node])
+	s.lazy[node] = s.ops.Identity
+}
+
+// Applies update to every value in [l, r)
+func (s *LazySegmentTree[T, U]) Update(l int, r int, update U) {
+	if l < r {
+		s.update(1, 0, s.n, l, r, update)
+	}
+}
+
+func (s *LazySegmentTree[T, U]) update(node int, lo int, hi int, l int, r int, update U) {
+	if r <= lo || hi <= l {
+		return
+	}
+	if l <= lo && hi <= r {
+		s.applyTo(node, hi-lo, update)
+		return
+	}
+	mid := (lo + hi) / 2
+	s.push(node, lo, mid, hi)
+	s.update(2*node, lo, mid, l, r, update)
+	s.update(2*node+1, mid, hi, l, r, update)
+	s.tree[node] = s.monoid.Combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// Combination of the values in [l, r), the identity for an empty range
+func (s *LazySegmentTree[T, U]) Query(l int, r int) T {
+	if l >= r {
+		return s.monoid.Identity
+	}
+	return s.query(1, 0, s.n, l, r)
+}
+
+func (s *LazySegmentTree[T, U]) query(node int, lo int, hi int, l int, r int) T {
+	if r <= lo || hi <= l {
+		return s.monoid.Identity
+	}
+	if l <= lo && hi <= r {
+		return s.tree[node]
+	}
+	mid := (lo + hi) / 2
+	s.push(node, lo, mid, hi)
+	return s.monoid.Combine(s.query(2*node, lo, mid, l, r), s.query(2*node+1, mid, hi, l, r))
+}
@@ -0,0 +1,193 @@
+package datastructures
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Returns a + b and false if the addition overflowed T
+func AddChecked[T constraints.Integer](a T, b T) (T, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return c, false
+	}
+	return c, true
+}
+
+// Returns a * b and false if the multiplication overflowed T
+func MulChecked[T constraints.Integer](a T, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || ((a < 0) != (b < 0)) != (c < 0) {
+		return c, false
+	}
+	return c, true
+}
+
+func mustMul[T constraints.Integer](a T, b T) T {
+	c, ok := MulChecked(a, b)
+	if !ok {
+		panic(fmt.Sprintf("Overflow when multiplying %d by %d", a, b))
+	}
+	return c
+}
+
+func mustAdd[T constraints.Integer](a T, b T) T {
+	c, ok := AddChecked(a, b)
+	if !ok {
+		panic(fmt.Sprintf("Overflow when adding %d to %d", b, a))
+	}
+	return c
+}
+
+// -a, the minimum of a signed type has no positive counterpart
+func mustNeg[T constraints.Integer](a T) T {
+	if a < 0 && -a < 0 {
+		panic(fmt.Sprintf("Overflow when negating %d", a))
+	}
+	return -a
+}
+
+// Always non negative, GCD(0, 0) = 0. Panics if the result doesn't fit in T (GCD(MinInt64, 0))
+func GCD[T constraints.Integer](a T, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		a = mustNeg(a)
+	}
+	return a
+}
+
+// Panics if the result doesn't fit in T
+func LCM[T constraints.Integer](a T, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	l := mustMul(a/GCD(a, b), b)
+	if l < 0 {
+		l = mustNeg(l)
+	}
+	return l
+}
+
+// Returns g = gcd(a, b) together with x and y such that a*x + b*y = g
+func ExtGCD[T constraints.Signed](a T, b T) (T, T, T) {
+	oldR, r := a, b
+	oldX, x := T(1), T(0)
+	oldY, y := T(0), T(1)
+
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// a mod m, always in [0, m)
+func Mod[T constraints.Integer](a T, m T) T {
+	if m <= 0 {
+		panic(fmt.Sprintf("Modulus must be positive, found %d", m))
+	}
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// a * b mod m without overflowing, whatever the size of a and b
+func MulMod[T constraints.Integer](a T, b T, m T) T {
+	ua := uint64(Mod(a, m))
+	ub := uint64(Mod(b, m))
+	hi, lo := bits.Mul64(ua, ub)
+	_, rem := bits.Div64(hi, lo, uint64(m))
+	return T(rem)
+}
+
+// b^e mod m by fast exponentiation, e must be non negative
+func ModPow[T constraints.Integer](b T, e T, m T) T {
+	if e < 0 {
+		panic(fmt.Sprintf("This function should only be called with positive integer exponent, found %d", e))
+	}
+
+	res := Mod(1, m)
+	b = Mod(b, m)
+	for e > 0 {
+		if e%2 == 1 {
+			res = MulMod(res, b, m)
+		}
+		b = MulMod(b, b, m)
+		e /= 2
+	}
+	return res
+}
+
+// Inverse of a modulo m, false if a and m are not coprime
+func ModInv[T constraints.Signed](a T, m T) (T, bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// Solves x = residues[i] (mod moduli[i]) for all i. The moduli don't have to be coprime.
+// Returns the smallest non negative x and the lcm of the moduli, or false if the system has no solution.
+func CRT[T constraints.Signed](residues []T, moduli []T) (T, T, bool) {
+	if len(residues) != len(moduli) {
+		panic(fmt.Sprintf("Expected as many residues as moduli, found %d and %d", len(residues), len(moduli)))
+	}
+
+	x, m := T(0), T(1)
+	for idx := range residues {
+		a, n := Mod(residues[idx], moduli[idx]), moduli[idx]
+
+		g, p, _ := ExtGCD(m, n)
+		if (a-x)%g != 0 {
+			return 0, 0, false
+		}
+
+		// x + m*k = a (mod n) => k = (a - x)/g * p (mod n/g)
+		step := n / g
+		k := MulMod((a-x)/g, p, step)
+		l := mustMul(m, step)
+		x = Mod(mustAdd(x, MulMod(m, k, l)), l)
+		m = l
+	}
+
+	return x, m, true
+}
+
+// Smallest r such that r*r >= n
+func IsqrtCeil[T constraints.Integer](n T) T {
+	if n < 0 {
+		panic(fmt.Sprintf("Can't take the square root of negative number %d", n))
+	}
+
+	r := T(math.Sqrt(float64(n)))
+	// the float estimate can be off by one in either direction for big n
+	for r > 0 && squareAtLeast(r-1, n) {
+		r--
+	}
+	for !squareAtLeast(r, n) {
+		r++
+	}
+	return r
+}
+
+func squareAtLeast[T constraints.Integer](r T, n T) bool {
+	sq, ok := MulChecked(r, r)
+	return !ok || sq >= n
+}
@@ -0,0 +1,96 @@
+package datastructures
+
+import (
+	"math"
+	"testing"
+)
+
+func expectPanic(t *testing.T, name string, f func()) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected %s to panic", name)
+		}
+	}()
+	f()
+}
+
+func TestCheckedOps(t *testing.T) {
+	if _, ok := MulChecked(int64(math.MaxInt64/2), 3); ok {
+		t.Fatalf("Expected MaxInt64/2 * 3 to overflow")
+	}
+
+	if _, ok := MulChecked(int64(math.MinInt64), -1); ok {
+		t.Fatalf("Expected MinInt64 * -1 to overflow")
+	}
+
+	if r, ok := MulChecked(-4, 5); !ok || r != -20 {
+		t.Fatalf("Expected -4 * 5 to be -20, it was %d instead", r)
+	}
+
+	if _, ok := AddChecked(uint8(200), 100); ok {
+		t.Fatalf("Expected 200 + 100 to overflow uint8")
+	}
+
+	expectPanic(t, "2^64", func() { Pow(2, 64) })
+	expectPanic(t, "LCM of big numbers", func() { LCM(int64(1)<<40, int64(1)<<40-1) })
+	expectPanic(t, "GCD of MinInt64 and 0", func() { GCD(int64(math.MinInt64), 0) })
+	expectPanic(t, "LCM of MinInt64 and 1", func() { LCM(int64(math.MinInt64), 1) })
+}
+
+func TestGCDAndLCM(t *testing.T) {
+	if r := GCD(-12, 18); r != 6 {
+		t.Fatalf("Expected gcd(-12, 18) to be 6, it was %d instead", r)
+	}
+
+	if r := LCM(4, 6); r != 12 {
+		t.Fatalf("Expected lcm(4, 6) to be 12, it was %d instead", r)
+	}
+
+	g, x, y := ExtGCD(240, 46)
+	if g != 2 || 240*x+46*y != 2 {
+		t.Fatalf("Expected 240*%d + 46*%d to be gcd 2, it was %d instead", x, y, g)
+	}
+}
+
+func TestModular(t *testing.T) {
+	if r := ModPow(3, 200, 1_000_000_007); r != 136318165 {
+		t.Fatalf("Expected 3^200 mod 1e9+7 to be 136318165, it was %d instead", r)
+	}
+
+	if r := ModPow(int64(math.MaxInt64), 2, math.MaxInt64-1); r != 1 {
+		t.Fatalf("Expected (m+1)^2 mod m to be 1, it was %d instead", r)
+	}
+
+	if r, ok := ModInv(3, 101); !ok || r*3%101 != 1 {
+		t.Fatalf("Expected the inverse of 3 mod 101 to exist, found %d", r)
+	}
+
+	if _, ok := ModInv(4, 10); ok {
+		t.Fatalf("Expected 4 to not be invertible mod 10")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	x, m, ok := CRT([]int{2, 3, 2}, []int{3, 5, 7})
+	if !ok || x != 23 || m != 105 {
+		t.Fatalf("Expected x = 23 mod 105, found %d mod %d instead", x, m)
+	}
+
+	// non coprime moduli
+	x, m, ok = CRT([]int{3, 7}, []int{4, 6})
+	if !ok || x != 7 || m != 12 {
+		t.Fatalf("Expected x = 7 mod 12, found %d mod %d instead", x, m)
+	}
+
+	if _, _, ok = CRT([]int{1, 2}, []int{4, 6}); ok {
+		t.Fatalf("Expected x = 1 mod 4, x = 2 mod 6 to have no solution")
+	}
+}
+
+func TestIsqrtCeil(t *testing.T) {
+	for _, tc := range [][2]int64{{0, 0}, {1, 1}, {15, 4}, {16, 4}, {17, 5}, {math.MaxInt64, 3037000500}} {
+		if r := IsqrtCeil(tc[0]); r != tc[1] {
+			t.Fatalf("Expected ceil(sqrt(%d)) to be %d, it was %d instead", tc[0], tc[1], r)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package datastructures
 
+import "iter"
+
 type Queue []Element
 
 func (q *Queue) Enqueue(element Element) {
@@ -22,3 +24,15 @@ func (q Queue) First() Element {
 func (q Queue) IsEmpty() bool {
 	return len(q) == 0
 }
+
+// Dequeues and yields elements until the queue is empty. Elements enqueued while
+// iterating are yielded as well, so a BFS is just a range over Drain.
+func (q *Queue) Drain() iter.Seq[Element] {
+	return func(yield func(Element) bool) {
+		for !q.IsEmpty() {
+			if !yield(q.Dequeue()) {
+				return
+			}
+		}
+	}
+}
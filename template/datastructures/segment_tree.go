@@ -0,0 +1,129 @@
+package datastructures
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Associative Combine with Identity as its neutral element
+type Monoid[T any] struct {
+	Identity T
+	Combine  func(a T, b T) T
+}
+
+func SumMonoid[T constraints.Integer | constraints.Float]() Monoid[T] {
+	return Monoid[T]{0, func(a T, b T) T { return a + b }}
+}
+
+// identity must be greater than or equal to any value stored in the tree
+func MinMonoid[T constraints.Ordered](identity T) Monoid[T] {
+	return Monoid[T]{identity, func(a T, b T) T { return min(a, b) }}
+}
+
+// identity must be smaller than or equal to any value stored in the tree
+func MaxMonoid[T constraints.Ordered](identity T) Monoid[T] {
+	return Monoid[T]{identity, func(a T, b T) T { return max(a, b) }}
+}
+
+// Point updates and range queries over any monoid, both in O(log n).
+// Ranges are half open, [l, r).
+type SegmentTree[T any] struct {
+	n      int
+	size   int
+	monoid Monoid[T]
+	tree   []T
+}
+
+func NewSegmentTree[T any](values []T, monoid Monoid[T]) *SegmentTree[T] {
+	size := 1
+	for size < len(values) {
+		size *= 2
+	}
+
+	tree := make([]T, 2*size)
+	for idx := range tree {
+		tree[idx] = monoid.Identity
+	}
+	copy(tree[size:], values)
+	for idx := size - 1; idx > 0; idx-- {
+		tree[idx] = monoid.Combine(tree[2*idx], tree[2*idx+1])
+	}
+
+	return &SegmentTree[T]{len(values), size, monoid, tree}
+}
+
+func (s *SegmentTree[T]) Len() int {
+	return s.n
+}
+
+func (s *SegmentTree[T]) checkIndex(idx int) {
+	if idx < 0 || idx >= s.n {
+		panic(fmt.Sprintf("Index %d is outside of the segment tree [0, %d)", idx, s.n))
+	}
+}
+
+func (s *SegmentTree[T]) Get(idx int) T {
+	s.checkIndex(idx)
+	return s.tree[s.size+idx]
+}
+
+func (s *SegmentTree[T]) Set(idx int, value T) {
+	s.checkIndex(idx)
+	idx += s.size
+	s.tree[idx] = value
+	for idx /= 2; idx > 0; idx /= 2 {
+		s.tree[idx] = s.monoid.Combine(s.tree[2*idx], s.tree[2*idx+1])
+	}
+}
+
+// Combination of the values in [l, r), the identity for an empty range
+func (s *SegmentTree[T]) Query(l int, r int) T {
+	left := s.monoid.Identity
+	right := s.monoid.Identity
+
+	// walk up from both ends, keeping the combination order for non commutative monoids
+	for l, r = l+s.size, r+s.size; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			left = s.monoid.Combine(left, s.tree[l])
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			right = s.monoid.Combine(s.tree[r], right)
+		}
+	}
+
+	return s.monoid.Combine(left, right)
+}
+
+// Smallest r >= l such that pred(Query(l, r + 1)) holds, false if there is none.
+// pred must be monotone: once true for a range it stays true for any longer one.
+// With MaxMonoid and pred = v >= k, this is the first index from l holding a value >= k.
+func (s *SegmentTree[T]) FirstWhere(l int, pred func(T) bool) (int, bool) {
+	acc := s.monoid.Identity
+	return s.firstWhere(1, 0, s.size, l, pred, &acc)
+}
+
+func (s *SegmentTree[T]) firstWhere(node int, lo int, hi int, l int, pred func(T) bool, acc *T) (int, bool) {
+	if hi <= l || lo >= s.n {
+		return -1, false
+	}
+
+	if lo >= l {
+		combined := s.monoid.Combine(*acc, s.tree[node])
+		if !pred(combined) {
+			*acc = combined
+			return -1, false
+		}
+		if hi-lo == 1 {
+			return lo, true
+		}
+	}
+
+	mid := (lo + hi) / 2
+	if idx, ok := s.firstWhere(2*node, lo, mid, l, pred, acc); ok {
+		return idx, true
+	}
+	return s.firstWhere(2*node+1, mid, hi, l, pred, acc)
+}
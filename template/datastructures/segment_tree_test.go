@@ -0,0 +1,71 @@
+package datastructures
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegmentTree(t *testing.T) {
+	values := []int{5, 2, 8, 1, 9, 3}
+
+	sums := NewSegmentTree(values, SumMonoid[int]())
+	if r := sums.Query(1, 4); r != 11 {
+		t.Fatalf("Expected sum of [1, 4) to be 11, it was %d instead", r)
+	}
+
+	sums.Set(2, 0)
+	if r := sums.Query(0, 6); r != 20 {
+		t.Fatalf("Expected total sum to be 20 after the update, it was %d instead", r)
+	}
+
+	mins := NewSegmentTree(values, MinMonoid(math.MaxInt))
+	if r := mins.Query(0, 3); r != 2 {
+		t.Fatalf("Expected min of [0, 3) to be 2, it was %d instead", r)
+	}
+
+	maxs := NewSegmentTree(values, MaxMonoid(math.MinInt))
+	atLeast := func(k int) func(int) bool { return func(v int) bool { return v >= k } }
+
+	if idx, ok := maxs.FirstWhere(0, atLeast(8)); !ok || idx != 2 {
+		t.Fatalf("Expected first value >= 8 to be at 2, found %d instead", idx)
+	}
+
+	if idx, ok := maxs.FirstWhere(3, atLeast(8)); !ok || idx != 4 {
+		t.Fatalf("Expected first value >= 8 from 3 to be at 4, found %d instead", idx)
+	}
+
+	if _, ok := maxs.FirstWhere(0, atLeast(10)); ok {
+		t.Fatalf("Expected no value >= 10")
+	}
+}
+
+func TestLazySegmentTree(t *testing.T) {
+	tree := NewLazySegmentTree([]int{1, 2, 3, 4, 5}, SumMonoid[int](), LazyOps[int, int]{
+		0,
+		func(u int, v int, length int) int { return v + u*length },
+		func(newer int, older int) int { return newer + older },
+	})
+
+	tree.Update(1, 4, 10)
+	tree.Update(0, 2, 1)
+
+	if r := tree.Query(0, 5); r != 47 {
+		t.Fatalf("Expected total sum to be 47, it was %d instead", r)
+	}
+
+	if r := tree.Query(1, 2); r != 13 {
+		t.Fatalf("Expected value at 1 to be 13, it was %d instead", r)
+	}
+}
+
+func TestFenwick(t *testing.T) {
+	f := NewFenwickFromArray([]int{3, 1, 4, 1, 5, 9})
+	if r := f.RangeSum(2, 5); r != 10 {
+		t.Fatalf("Expected sum of [2, 5) to be 10, it was %d instead", r)
+	}
+
+	f.Add(3, 10)
+	if r := f.PrefixSum(6); r != 33 {
+		t.Fatalf("Expected total sum to be 33, it was %d instead", r)
+	}
+}
@@ -0,0 +1,82 @@
+package datastructures
+
+type trieNode struct {
+	children map[byte]*trieNode
+	terminal bool
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: map[byte]*trieNode{}}
+}
+
+// Prefix tree over the bytes of the inserted words
+type Trie struct {
+	root *trieNode
+	size int
+}
+
+func NewTrie(words ...string) *Trie {
+	t := &Trie{root: newTrieNode()}
+	for _, word := range words {
+		t.Insert(word)
+	}
+	return t
+}
+
+func (t *Trie) Insert(word string) {
+	node := t.root
+	for idx := 0; idx < len(word); idx++ {
+		next, ok := node.children[word[idx]]
+		if !ok {
+			next = newTrieNode()
+			node.children[word[idx]] = next
+		}
+		node = next
+	}
+	if !node.terminal {
+		node.terminal = true
+		t.size++
+	}
+}
+
+func (t *Trie) Contains(word string) bool {
+	node := t.root
+	for idx := 0; idx < len(word) && node != nil; idx++ {
+		node = node.children[word[idx]]
+	}
+	return node != nil && node.terminal
+}
+
+// Number of distinct words
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Lengths of all the words that s[pos:] starts with, shortest first
+func (t *Trie) PrefixesAt(s string, pos int) []int {
+	res := []int{}
+	if t.root.terminal {
+		res = append(res, 0)
+	}
+
+	node := t.root
+	for idx := pos; idx < len(s); idx++ {
+		node = node.children[s[idx]]
+		if node == nil {
+			break
+		}
+		if node.terminal {
+			res = append(res, idx-pos+1)
+		}
+	}
+	return res
+}
+
+// Length of the longest word that s[pos:] starts with, false if there is none
+func (t *Trie) LongestPrefixAt(s string, pos int) (int, bool) {
+	prefixes := t.PrefixesAt(s, pos)
+	if len(prefixes) == 0 {
+		return 0, false
+	}
+	return prefixes[len(prefixes)-1], true
+}
@@ -0,0 +1,47 @@
+package datastructures
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	trie := NewTrie("r", "wr", "b", "g", "bwu", "rb", "gb", "br")
+
+	if !trie.Contains("bwu") || trie.Contains("bw") {
+		t.Fatalf("Expected bwu to be in the trie and bw to not be")
+	}
+
+	prefixes := trie.PrefixesAt("xbrwrr", 1)
+	if !slices.Equal(prefixes, []int{1, 2}) {
+		t.Fatalf("Expected prefixes at 1 to be [1 2], found %v instead", prefixes)
+	}
+
+	// day 19: number of ways to build a design out of towels
+	design := "gbbr"
+	ways := make([]int, len(design)+1)
+	ways[0] = 1
+	for idx := 0; idx < len(design); idx++ {
+		for _, length := range trie.PrefixesAt(design, idx) {
+			ways[idx+length] += ways[idx]
+		}
+	}
+	if ways[len(design)] != 4 {
+		t.Fatalf("Expected 4 ways to make gbbr, found %d instead", ways[len(design)])
+	}
+}
+
+func TestAhoCorasick(t *testing.T) {
+	ac := NewAhoCorasick([]string{"he", "she", "his", "hers"})
+	matches := ac.FindAll("ahishers")
+
+	expected := []Match{{2, 1, 4}, {1, 3, 6}, {0, 4, 6}, {3, 4, 8}}
+	if !slices.Equal(matches, expected) {
+		t.Fatalf("Expected matches %v, found %v instead", expected, matches)
+	}
+
+	overlapping := NewAhoCorasick([]string{"XMAS", "SAMX"}).FindAll("XMASAMX")
+	if len(overlapping) != 2 {
+		t.Fatalf("Expected 2 overlapping matches, found %v instead", overlapping)
+	}
+}
@@ -30,8 +30,19 @@ func pow[B constraints.Integer | constraints.Float, E constraints.Integer](b B,
 	}
 
 	halfPow := pow(b, e/2)
+	res := mulNoOverflow(halfPow, halfPow)
 	if e%2 == 0 {
-		return halfPow * halfPow
+		return res
 	}
-	return b * halfPow * halfPow
+	return mulNoOverflow(b, res)
+}
+
+// Panics when integer multiplication wraps around, floats are left alone
+func mulNoOverflow[B constraints.Integer | constraints.Float](a B, b B) B {
+	c := a * b
+	isInteger := B(1)/2 == 0
+	if isInteger && a != 0 && (c/a != b || ((a < 0) != (b < 0)) != (c < 0)) {
+		panic(fmt.Sprintf("Overflow when multiplying %v by %v", a, b))
+	}
+	return c
 }
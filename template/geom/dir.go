@@ -0,0 +1,62 @@
+package geom
+
+import "fmt"
+
+type Dir int
+
+// Ordered clockwise, so rotations are just +1/-1 modulo 4
+const (
+	UP Dir = iota
+	RIGHT
+	DOWN
+	LEFT
+)
+
+var ALL_DIRS = []Dir{UP, RIGHT, DOWN, LEFT}
+
+var DIR_TO_OFFSET = [4]Point{
+	UP:    {-1, 0},
+	RIGHT: {0, 1},
+	DOWN:  {1, 0},
+	LEFT:  {0, -1},
+}
+
+// Accepts both the arrow notation (^>v<) and the compass one (NESW)
+func ParseDir(r rune) (Dir, error) {
+	switch r {
+	case '^', 'N':
+		return UP, nil
+	case '>', 'E':
+		return RIGHT, nil
+	case 'v', 'S':
+		return DOWN, nil
+	case '<', 'W':
+		return LEFT, nil
+	default:
+		return UP, fmt.Errorf("Can't parse %q as a direction, expected one of ^>v< or NESW", r)
+	}
+}
+
+func (d Dir) Offset() Point {
+	return DIR_TO_OFFSET[d]
+}
+
+func (d Dir) RotateCW() Dir {
+	return (d + 1) % 4
+}
+
+func (d Dir) RotateCCW() Dir {
+	return (d + 3) % 4
+}
+
+func (d Dir) Opposite() Dir {
+	return (d + 2) % 4
+}
+
+func (d Dir) Arrow() rune {
+	return []rune{'^', '>', 'v', '<'}[d]
+}
+
+func (d Dir) String() string {
+	return []string{"UP", "RIGHT", "DOWN", "LEFT"}[d]
+}
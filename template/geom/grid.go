@@ -0,0 +1,129 @@
+package geom
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Dense grid indexed the same way as the rest of the code, grid[x][y] with x the line
+type Grid[T any] [][]T
+
+func NewGrid[T any](lines int, cols int, fill T) Grid[T] {
+	res := make(Grid[T], lines)
+	for idx := range res {
+		res[idx] = make([]T, cols)
+		for jdx := range res[idx] {
+			res[idx][jdx] = fill
+		}
+	}
+	return res
+}
+
+func GridFromLines(lines []string) Grid[rune] {
+	res := Grid[rune]{}
+	for _, line := range lines {
+		res = append(res, []rune(line))
+	}
+	return res
+}
+
+func (g Grid[T]) Lines() int {
+	return len(g)
+}
+
+func (g Grid[T]) Cols() int {
+	if len(g) == 0 {
+		return 0
+	}
+	return len(g[0])
+}
+
+func (g Grid[T]) InBounds(p Point) bool {
+	return p.InBounds(g.Lines(), g.Cols())
+}
+
+func (g Grid[T]) At(p Point) T {
+	return g[p.X][p.Y]
+}
+
+func (g Grid[T]) Set(p Point, v T) {
+	g[p.X][p.Y] = v
+}
+
+func (g Grid[T]) Clone() Grid[T] {
+	res := make(Grid[T], len(g))
+	for idx, line := range g {
+		res[idx] = append([]T{}, line...)
+	}
+	return res
+}
+
+// Every cell, line by line
+func (g Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for idx, line := range g {
+			for jdx, v := range line {
+				if !yield(Point{idx, jdx}, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Cells whose value matches, line by line
+func (g Grid[T]) Find(match func(T) bool) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for p, v := range g.All() {
+			if match(v) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// The up to 4 orthogonal neighbours of p that are inside the grid, in ALL_DIRS order
+func (g Grid[T]) Neighbours(p Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range ALL_DIRS {
+			adj := p.Move(d)
+			if g.InBounds(adj) && !yield(adj) {
+				return
+			}
+		}
+	}
+}
+
+// The up to 8 neighbours of p, diagonals included, that are inside the grid
+func (g Grid[T]) Neighbours8(p Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				adj := Point{p.X + dx, p.Y + dy}
+				if (dx != 0 || dy != 0) && g.InBounds(adj) && !yield(adj) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (g Grid[T]) String() string {
+	var sb strings.Builder
+	for _, line := range g {
+		for _, v := range line {
+			sb.WriteString(fmtCell(v))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Runes are printed as characters, anything else with its default format
+func fmtCell(v any) string {
+	if r, ok := v.(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,43 @@
+package geom
+
+import "aoc-2024/datastructures"
+
+// Point on a grid, x is the line (row) and y is the column, same as matrix[x][y]
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+func (p Point) Manhattan(q Point) int {
+	return datastructures.Abs(p.X-q.X) + datastructures.Abs(p.Y-q.Y)
+}
+
+// Rotates the vector by 90 degrees clockwise, as seen on screen (UP -> RIGHT -> DOWN -> LEFT)
+func (p Point) RotateCW() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Rotates the vector by 90 degrees counter clockwise, as seen on screen (UP -> LEFT -> DOWN -> RIGHT)
+func (p Point) RotateCCW() Point {
+	return Point{-p.Y, p.X}
+}
+
+func (p Point) Move(d Dir) Point {
+	return p.Add(d.Offset())
+}
+
+func (p Point) InBounds(lines int, cols int) bool {
+	return p.X >= 0 && p.X < lines && p.Y >= 0 && p.Y < cols
+}
@@ -0,0 +1,72 @@
+package geom
+
+import (
+	"testing"
+)
+
+func TestRotations(t *testing.T) {
+	for _, d := range ALL_DIRS {
+		if d.Offset().RotateCW() != d.RotateCW().Offset() {
+			t.Fatalf("Expected rotating the offset of %s clockwise to be %v, it was %v instead", d, d.RotateCW().Offset(), d.Offset().RotateCW())
+		}
+		if d.Offset().RotateCCW() != d.RotateCCW().Offset() {
+			t.Fatalf("Expected rotating the offset of %s counter clockwise to be %v, it was %v instead", d, d.RotateCCW().Offset(), d.Offset().RotateCCW())
+		}
+		if d.Offset().Add(d.Opposite().Offset()) != (Point{0, 0}) {
+			t.Fatalf("Expected %s and %s to cancel out", d, d.Opposite())
+		}
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	for idx, r := range "^>v<" {
+		d, err := ParseDir(r)
+		if err != nil || d != ALL_DIRS[idx] || d.Arrow() != r {
+			t.Fatalf("Expected %c to be parsed as %s, it was %s instead", r, ALL_DIRS[idx], d)
+		}
+	}
+
+	d, _ := ParseDir('W')
+	if d != LEFT {
+		t.Fatalf("Expected W to be parsed as LEFT, it was %s instead", d)
+	}
+
+	if _, err := ParseDir('x'); err == nil {
+		t.Fatalf("Expected x to not be a valid direction")
+	}
+}
+
+func TestManhattan(t *testing.T) {
+	p := Point{1, 2}.Add(DOWN.Offset().Scale(3))
+	if p != (Point{4, 2}) {
+		t.Fatalf("Expected (1, 2) + 3 * DOWN to be (4, 2), it was %v instead", p)
+	}
+
+	dist := p.Manhattan(Point{-1, 5})
+	if dist != 8 {
+		t.Fatalf("Expected distance from (4, 2) to (-1, 5) to be 8, it was %d instead", dist)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := GridFromLines([]string{"#.", ".."})
+
+	cells := 0
+	for p, v := range g.All() {
+		if g.At(p) != v {
+			t.Fatalf("Expected value at %v to be %c, it was %c instead", p, g.At(p), v)
+		}
+		cells++
+	}
+	if cells != 4 {
+		t.Fatalf("Expected 4 cells, found %d instead", cells)
+	}
+
+	neighbours := []Point{}
+	for adj := range g.Neighbours(Point{0, 0}) {
+		neighbours = append(neighbours, adj)
+	}
+	if len(neighbours) != 2 || neighbours[0] != (Point{0, 1}) {
+		t.Fatalf("Expected neighbours of (0, 0) to be [(0, 1) (1, 0)], found %v instead", neighbours)
+	}
+}
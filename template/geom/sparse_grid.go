@@ -0,0 +1,162 @@
+package geom
+
+import (
+	"iter"
+	"strings"
+)
+
+// Inclusive rectangle of points, Min is the top left corner and Max the bottom right one
+type Bounds struct {
+	Min Point
+	Max Point
+}
+
+func (b Bounds) Contains(p Point) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+func (b Bounds) Lines() int {
+	return b.Max.X - b.Min.X + 1
+}
+
+func (b Bounds) Cols() int {
+	return b.Max.Y - b.Min.Y + 1
+}
+
+// Unbounded grid that only stores the cells that were set, every other cell holds the default value
+type SparseGrid[T comparable] struct {
+	cells map[Point]T
+	def   T
+	// cached bounding box, recomputed lazily after a delete on its edge
+	bounds Bounds
+	dirty  bool
+}
+
+func NewSparseGrid[T comparable](def T) *SparseGrid[T] {
+	return &SparseGrid[T]{cells: map[Point]T{}, def: def}
+}
+
+// Stores every cell of g that doesn't hold def, with g[0][0] at offset
+func SparseGridFromGrid[T comparable](g Grid[T], def T, offset Point) *SparseGrid[T] {
+	s := NewSparseGrid(def)
+	for p, v := range g.All() {
+		if v != def {
+			s.Set(p.Add(offset), v)
+		}
+	}
+	return s
+}
+
+func (s *SparseGrid[T]) Default() T {
+	return s.def
+}
+
+func (s *SparseGrid[T]) At(p Point) T {
+	if v, ok := s.cells[p]; ok {
+		return v
+	}
+	return s.def
+}
+
+func (s *SparseGrid[T]) Has(p Point) bool {
+	_, ok := s.cells[p]
+	return ok
+}
+
+// Setting a cell to the default value removes it
+func (s *SparseGrid[T]) Set(p Point, v T) {
+	if v == s.def {
+		s.Delete(p)
+		return
+	}
+
+	if len(s.cells) == 0 && !s.dirty {
+		s.bounds = Bounds{p, p}
+	} else if !s.dirty {
+		s.bounds.Min = Point{min(s.bounds.Min.X, p.X), min(s.bounds.Min.Y, p.Y)}
+		s.bounds.Max = Point{max(s.bounds.Max.X, p.X), max(s.bounds.Max.Y, p.Y)}
+	}
+	s.cells[p] = v
+}
+
+func (s *SparseGrid[T]) Delete(p Point) {
+	if _, ok := s.cells[p]; !ok {
+		return
+	}
+	delete(s.cells, p)
+	if p.X == s.bounds.Min.X || p.X == s.bounds.Max.X || p.Y == s.bounds.Min.Y || p.Y == s.bounds.Max.Y {
+		s.dirty = true
+	}
+}
+
+// Number of stored cells
+func (s *SparseGrid[T]) Len() int {
+	return len(s.cells)
+}
+
+// Smallest rectangle holding every stored cell, false if the grid is empty
+func (s *SparseGrid[T]) Bounds() (Bounds, bool) {
+	if len(s.cells) == 0 {
+		return Bounds{}, false
+	}
+
+	if s.dirty {
+		first := true
+		for p := range s.cells {
+			if first {
+				s.bounds = Bounds{p, p}
+				first = false
+				continue
+			}
+			s.bounds.Min = Point{min(s.bounds.Min.X, p.X), min(s.bounds.Min.Y, p.Y)}
+			s.bounds.Max = Point{max(s.bounds.Max.X, p.X), max(s.bounds.Max.Y, p.Y)}
+		}
+		s.dirty = false
+	}
+	return s.bounds, true
+}
+
+// Stored cells, in no particular order
+func (s *SparseGrid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for p, v := range s.cells {
+			if !yield(p, v) {
+				return
+			}
+		}
+	}
+}
+
+// The 4 orthogonal neighbours of p, the grid has no edges so there are always 4
+func (s *SparseGrid[T]) Neighbours(p Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, d := range ALL_DIRS {
+			if !yield(p.Move(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Dense copy of the cells inside bounds, bounds.Min ends up at [0][0]
+func (s *SparseGrid[T]) ToGrid(bounds Bounds) Grid[T] {
+	g := NewGrid(bounds.Lines(), bounds.Cols(), s.def)
+	for p, v := range s.cells {
+		if bounds.Contains(p) {
+			g.Set(p.Sub(bounds.Min), v)
+		}
+	}
+	return g
+}
+
+// One line of text per grid line inside bounds, each cell drawn with format
+func (s *SparseGrid[T]) Render(bounds Bounds, format func(T) rune) string {
+	var sb strings.Builder
+	for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+			sb.WriteRune(format(s.At(Point{x, y})))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
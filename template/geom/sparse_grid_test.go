@@ -0,0 +1,35 @@
+package geom
+
+import (
+	"testing"
+)
+
+func TestSparseGrid(t *testing.T) {
+	s := NewSparseGrid('.')
+	s.Set(Point{-2, 3}, '#')
+	s.Set(Point{1, -1}, '#')
+	s.Set(Point{5, 5}, '#')
+
+	bounds, ok := s.Bounds()
+	if !ok || bounds != (Bounds{Point{-2, -1}, Point{5, 5}}) {
+		t.Fatalf("Expected bounds from (-2, -1) to (5, 5), found %v instead", bounds)
+	}
+
+	s.Set(Point{5, 5}, '.')
+	bounds, _ = s.Bounds()
+	if s.Len() != 2 || bounds.Max != (Point{1, 3}) {
+		t.Fatalf("Expected bounds to shrink to (1, 3) after clearing (5, 5), found %v instead", bounds)
+	}
+
+	render := s.Render(bounds, func(r rune) rune { return r })
+	expected := "....#\n.....\n.....\n#....\n"
+	if render != expected {
+		t.Fatalf("Expected render to be\n%s\nfound\n%s\ninstead", expected, render)
+	}
+
+	g := s.ToGrid(bounds)
+	back := SparseGridFromGrid(g, '.', bounds.Min)
+	if back.Len() != 2 || back.At(Point{-2, 3}) != '#' {
+		t.Fatalf("Expected converting to a dense grid and back to keep the 2 cells")
+	}
+}
@@ -2,4 +2,4 @@ module aoc-2024
 
 go 1.23.3
 
-require golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f // indirect
+require golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f
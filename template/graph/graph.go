@@ -0,0 +1,74 @@
+package graph
+
+// Anything that can tell the neighbours of a node and the cost of going to one of them.
+// Implicit graphs (grids, state spaces) only need to implement this.
+type Graph[N comparable] interface {
+	Neighbours(node N) []N
+	Weight(from N, to N) int
+}
+
+// A graph for which all the nodes are known upfront, needed by the whole-graph algorithms
+type FiniteGraph[N comparable] interface {
+	Graph[N]
+	Nodes() []N
+}
+
+type Edge[N comparable] struct {
+	To     N
+	Weight int
+}
+
+type AdjacencyList[N comparable] map[N][]Edge[N]
+
+func NewAdjacencyList[N comparable]() AdjacencyList[N] {
+	return AdjacencyList[N]{}
+}
+
+func (g AdjacencyList[N]) AddNode(node N) {
+	if _, ok := g[node]; !ok {
+		g[node] = []Edge[N]{}
+	}
+}
+
+func (g AdjacencyList[N]) AddEdge(from N, to N, weight int) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g[from] = append(g[from], Edge[N]{to, weight})
+}
+
+func (g AdjacencyList[N]) AddUndirectedEdge(a N, b N, weight int) {
+	g.AddEdge(a, b, weight)
+	g.AddEdge(b, a, weight)
+}
+
+func (g AdjacencyList[N]) Neighbours(node N) []N {
+	res := make([]N, 0, len(g[node]))
+	for _, edge := range g[node] {
+		res = append(res, edge.To)
+	}
+	return res
+}
+
+// Returns the cheapest edge between from and to, panics if there is none
+func (g AdjacencyList[N]) Weight(from N, to N) int {
+	found := false
+	weight := 0
+	for _, edge := range g[from] {
+		if edge.To == to && (!found || edge.Weight < weight) {
+			found = true
+			weight = edge.Weight
+		}
+	}
+	if !found {
+		panic("Trying to get the weight of a missing edge")
+	}
+	return weight
+}
+
+func (g AdjacencyList[N]) Nodes() []N {
+	res := make([]N, 0, len(g))
+	for node := range g {
+		res = append(res, node)
+	}
+	return res
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDijkstraAllPredecessors(t *testing.T) {
+	g := NewAdjacencyList[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddEdge("b", "d", 2)
+	g.AddEdge("c", "d", 1)
+	g.AddEdge("a", "d", 5)
+
+	dist, prev := Dijkstra(g, "a")
+
+	if dist["d"] != 3 {
+		t.Fatalf("Expected distance to d to be 3, it was %d instead", dist["d"])
+	}
+
+	preds := slices.Clone(prev["d"])
+	slices.Sort(preds)
+	if !slices.Equal(preds, []string{"b", "c"}) {
+		t.Fatalf("Expected predecessors of d to be [b c], they were %v instead", preds)
+	}
+
+	path, cost, found := AStar(g, "a", func(n string) bool { return n == "d" }, func(string) int { return 0 })
+	if !found || cost != 3 || len(path) != 3 {
+		t.Fatalf("Expected A* to find a path of cost 3 through 3 nodes, found %v with cost %d instead", path, cost)
+	}
+}
+
+func TestBFS(t *testing.T) {
+	g := NewAdjacencyList[int]()
+	for idx := 0; idx < 5; idx++ {
+		g.AddUndirectedEdge(idx, idx+1, 1)
+	}
+	g.AddNode(10)
+
+	dist, parent := BFS(g, 0)
+	if dist[5] != 5 {
+		t.Fatalf("Expected distance to 5 to be 5, it was %d instead", dist[5])
+	}
+
+	path := PathTo(parent, 0, 3)
+	if !slices.Equal(path, []int{0, 1, 2, 3}) {
+		t.Fatalf("Expected path to 3 to be [0 1 2 3], it was %v instead", path)
+	}
+
+	if PathTo(parent, 0, 10) != nil {
+		t.Fatalf("Expected 10 to not be reachable")
+	}
+
+	if len(Components(g)) != 2 {
+		t.Fatalf("Expected 2 components, found %d instead", len(Components(g)))
+	}
+}
+
+func TestTopoSortAndSCC(t *testing.T) {
+	g := NewAdjacencyList[int]()
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(1, 3, 1)
+
+	order, err := TopoSort(g)
+	if err != nil || !slices.Equal(order, []int{1, 2, 3}) {
+		t.Fatalf("Expected topo sort to be [1 2 3], it was %v (%v) instead", order, err)
+	}
+
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 2, 1)
+
+	_, err = TopoSort(g)
+	var cycleErr *CycleError[int]
+	if !errors.As(err, &cycleErr) || len(cycleErr.Remaining) != 3 {
+		t.Fatalf("Expected a cycle error with 3 remaining nodes, got %v instead", err)
+	}
+
+	sccs := SCC(g)
+	if len(sccs) != 2 {
+		t.Fatalf("Expected 2 strongly connected components, found %v instead", sccs)
+	}
+}
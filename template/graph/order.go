@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"aoc-2024/datastructures"
+	"fmt"
+)
+
+// Returned by TopoSort when the graph is not a DAG. Remaining holds the nodes that
+// could not be ordered, i.e. the ones on a cycle or reachable from one.
+type CycleError[N comparable] struct {
+	Remaining []N
+}
+
+func (e *CycleError[N]) Error() string {
+	return fmt.Sprintf("Graph has a cycle, %d nodes could not be ordered", len(e.Remaining))
+}
+
+// Kahn's algorithm. On a cycle it returns the partial ordering it managed to build
+// together with a *CycleError describing what was left out.
+func TopoSort[N comparable](g FiniteGraph[N]) ([]N, error) {
+	nodes := g.Nodes()
+	deg := map[N]int{}
+	for _, node := range nodes {
+		deg[node] += 0
+		for _, adj := range g.Neighbours(node) {
+			deg[adj] += 1
+		}
+	}
+
+	queue := datastructures.Queue{}
+	for _, node := range nodes {
+		if deg[node] == 0 {
+			queue.Enqueue(node)
+		}
+	}
+
+	topoSort := []N{}
+	for !queue.IsEmpty() {
+		node := queue.Dequeue().(N)
+		topoSort = append(topoSort, node)
+		for _, adj := range g.Neighbours(node) {
+			deg[adj] -= 1
+			if deg[adj] == 0 {
+				queue.Enqueue(adj)
+			}
+		}
+	}
+
+	if len(topoSort) != len(deg) {
+		remaining := []N{}
+		for node, inDegree := range deg {
+			if inDegree > 0 {
+				remaining = append(remaining, node)
+			}
+		}
+		return topoSort, &CycleError[N]{remaining}
+	}
+
+	return topoSort, nil
+}
+
+type tarjanState[N comparable] struct {
+	g       Graph[N]
+	index   map[N]int
+	lowLink map[N]int
+	onStack map[N]bool
+	stack   []N
+	sccs    [][]N
+}
+
+func (s *tarjanState[N]) strongConnect(node N) {
+	s.index[node] = len(s.index)
+	s.lowLink[node] = s.index[node]
+	s.stack = append(s.stack, node)
+	s.onStack[node] = true
+
+	for _, adj := range s.g.Neighbours(node) {
+		if _, ok := s.index[adj]; !ok {
+			s.strongConnect(adj)
+			s.lowLink[node] = min(s.lowLink[node], s.lowLink[adj])
+		} else if s.onStack[adj] {
+			s.lowLink[node] = min(s.lowLink[node], s.index[adj])
+		}
+	}
+
+	if s.lowLink[node] != s.index[node] {
+		return
+	}
+
+	scc := []N{}
+	for {
+		top := s.stack[len(s.stack)-1]
+		s.stack = s.stack[:len(s.stack)-1]
+		s.onStack[top] = false
+		scc = append(scc, top)
+		if top == node {
+			break
+		}
+	}
+	s.sccs = append(s.sccs, scc)
+}
+
+// Tarjan's strongly connected components, returned in reverse topological order
+// of the condensed graph (an SCC comes before the ones that can reach it)
+func SCC[N comparable](g FiniteGraph[N]) [][]N {
+	s := &tarjanState[N]{
+		g:       g,
+		index:   map[N]int{},
+		lowLink: map[N]int{},
+		onStack: map[N]bool{},
+	}
+
+	for _, node := range g.Nodes() {
+		if _, ok := s.index[node]; !ok {
+			s.strongConnect(node)
+		}
+	}
+
+	return s.sccs
+}
+
+// Connected components, edge directions are ignored (weakly connected components for directed graphs)
+func Components[N comparable](g FiniteGraph[N]) [][]N {
+	dsu := datastructures.NewDSU[N]()
+	for _, node := range g.Nodes() {
+		dsu.Add(node)
+		for _, adj := range g.Neighbours(node) {
+			dsu.Union(node, adj)
+		}
+	}
+
+	res := [][]N{}
+	for _, component := range dsu.Components() {
+		res = append(res, component)
+	}
+	return res
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"aoc-2024/datastructures"
+	"slices"
+)
+
+type nodeWithCost[N comparable] struct {
+	node N
+	cost int
+}
+
+func (a nodeWithCost[N]) CompareWith(e datastructures.ElementWithPriority) int {
+	b := e.(nodeWithCost[N])
+
+	if a.cost < b.cost {
+		return -1
+	}
+
+	if a.cost > b.cost {
+		return 1
+	}
+
+	return 0
+}
+
+// Unweighted shortest paths from start. Returns the distance (in edges) to every reachable node
+// and the node each one was discovered from, the start node has no parent.
+func BFS[N comparable](g Graph[N], start N) (map[N]int, map[N]N) {
+	dist := map[N]int{start: 0}
+	parent := map[N]N{}
+
+	queue := datastructures.Queue{}
+	queue.Enqueue(start)
+
+	for !queue.IsEmpty() {
+		node := queue.Dequeue().(N)
+
+		for _, adj := range g.Neighbours(node) {
+			if _, ok := dist[adj]; !ok {
+				dist[adj] = dist[node] + 1
+				parent[adj] = node
+				queue.Enqueue(adj)
+			}
+		}
+	}
+
+	return dist, parent
+}
+
+// Walks the parent map back from target, returns nil if target was never reached
+func PathTo[N comparable](parent map[N]N, start N, target N) []N {
+	path := []N{target}
+	for node := target; node != start; {
+		prev, ok := parent[node]
+		if !ok {
+			return nil
+		}
+		path = append(path, prev)
+		node = prev
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// Shortest paths from start for non negative weights. Besides the distances it returns,
+// for each node, all the predecessors that lie on some shortest path to it, so
+// every shortest path can be rebuilt by walking them back.
+func Dijkstra[N comparable](g Graph[N], start N) (map[N]int, map[N][]N) {
+	dist := map[N]int{start: 0}
+	prev := map[N][]N{}
+
+	pq := datastructures.PriorityQueue{nodeWithCost[N]{start, 0}}
+
+	for len(pq) != 0 {
+		curr := (*pq.Remove()).(nodeWithCost[N])
+
+		if curr.cost != dist[curr.node] {
+			continue
+		}
+
+		for _, adj := range g.Neighbours(curr.node) {
+			newCost := curr.cost + g.Weight(curr.node, adj)
+			oldCost, seen := dist[adj]
+
+			if !seen || newCost < oldCost {
+				dist[adj] = newCost
+				prev[adj] = []N{curr.node}
+				pq.Insert(nodeWithCost[N]{adj, newCost})
+			} else if newCost == oldCost && !slices.Contains(prev[adj], curr.node) {
+				prev[adj] = append(prev[adj], curr.node)
+			}
+		}
+	}
+
+	return dist, prev
+}
+
+// A* search from start until isGoal holds. The heuristic must never overestimate the remaining
+// cost for the returned path to be optimal. Returns the path, its cost and whether a goal was reached.
+func AStar[N comparable](g Graph[N], start N, isGoal func(N) bool, heuristic func(N) int) ([]N, int, bool) {
+	dist := map[N]int{start: 0}
+	parent := map[N]N{}
+
+	pq := datastructures.PriorityQueue{nodeWithCost[N]{start, heuristic(start)}}
+
+	for len(pq) != 0 {
+		curr := (*pq.Remove()).(nodeWithCost[N])
+		node := curr.node
+
+		if curr.cost != dist[node]+heuristic(node) {
+			continue
+		}
+
+		if isGoal(node) {
+			return PathTo(parent, start, node), dist[node], true
+		}
+
+		for _, adj := range g.Neighbours(node) {
+			newCost := dist[node] + g.Weight(node, adj)
+			if oldCost, seen := dist[adj]; !seen || newCost < oldCost {
+				dist[adj] = newCost
+				parent[adj] = node
+				pq.Insert(nodeWithCost[N]{adj, newCost + heuristic(adj)})
+			}
+		}
+	}
+
+	return nil, 0, false
+}
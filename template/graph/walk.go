@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"aoc-2024/datastructures"
+	"iter"
+)
+
+// Nodes reachable from start in BFS order, together with their distance in edges from start
+func Walk[N comparable](g Graph[N], start N) iter.Seq2[N, int] {
+	return func(yield func(N, int) bool) {
+		dist := map[N]int{start: 0}
+		queue := datastructures.Queue{start}
+
+		for e := range queue.Drain() {
+			node := e.(N)
+			if !yield(node, dist[node]) {
+				return
+			}
+			for _, adj := range g.Neighbours(node) {
+				if _, ok := dist[adj]; !ok {
+					dist[adj] = dist[node] + 1
+					queue.Enqueue(adj)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package linalg
+
+import (
+	"aoc-2024/datastructures"
+	"fmt"
+)
+
+// Determinant of a square integer matrix using fraction free Bareiss elimination.
+// Every intermediate value is a minor of the matrix, so it stays exact; false means an int64 overflow.
+func DetInts(a [][]int64) (int64, bool) {
+	n := len(a)
+	if n == 0 {
+		return 1, true
+	}
+
+	m := make([][]int64, n)
+	for idx := range a {
+		if len(a[idx]) != n {
+			panic(fmt.Sprintf("Determinant is only defined for square matrices, line %d has %d columns instead of %d", idx, len(a[idx]), n))
+		}
+		m[idx] = append([]int64{}, a[idx]...)
+	}
+
+	sign := int64(1)
+	prev := int64(1)
+	for k := 0; k < n-1; k++ {
+		if m[k][k] == 0 {
+			swap := -1
+			for idx := k + 1; idx < n; idx++ {
+				if m[idx][k] != 0 {
+					swap = idx
+					break
+				}
+			}
+			if swap < 0 {
+				return 0, true
+			}
+			m[k], m[swap] = m[swap], m[k]
+			sign = -sign
+		}
+
+		for idx := k + 1; idx < n; idx++ {
+			for jdx := k + 1; jdx < n; jdx++ {
+				left, ok1 := datastructures.MulChecked(m[idx][jdx], m[k][k])
+				right, ok2 := datastructures.MulChecked(m[idx][k], m[k][jdx])
+				// -right itself overflows for MinInt64
+				diff, ok3 := datastructures.AddChecked(left, -right)
+				if !ok1 || !ok2 || !ok3 || (right != 0 && right == -right) {
+					return 0, false
+				}
+				m[idx][jdx] = diff / prev
+			}
+		}
+		prev = m[k][k]
+	}
+
+	return sign * m[n-1][n-1], true
+}
@@ -0,0 +1,102 @@
+package linalg
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Reduced row echelon form by Gauss-Jordan elimination. Returns a new matrix, the
+// pivot column of every non zero line, and the sign of the permutation applied to the lines.
+func (m *Matrix) RREF() (*Matrix, []int, int) {
+	res := m.Clone()
+	pivots := []int{}
+	sign := 1
+	tmp := new(big.Rat)
+
+	line := 0
+	for col := 0; col < res.cols && line < res.rows; col++ {
+		pivot := -1
+		for idx := line; idx < res.rows; idx++ {
+			if res.At(idx, col).Sign() != 0 {
+				pivot = idx
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		if pivot != line {
+			res.swapRows(pivot, line)
+			sign = -sign
+		}
+
+		inv := new(big.Rat).Inv(res.At(line, col))
+		for jdx := col; jdx < res.cols; jdx++ {
+			res.At(line, jdx).Mul(res.At(line, jdx), inv)
+		}
+
+		for idx := 0; idx < res.rows; idx++ {
+			if idx == line || res.At(idx, col).Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(res.At(idx, col))
+			for jdx := col; jdx < res.cols; jdx++ {
+				tmp.Mul(factor, res.At(line, jdx))
+				res.At(idx, jdx).Sub(res.At(idx, jdx), tmp)
+			}
+		}
+
+		pivots = append(pivots, col)
+		line++
+	}
+
+	return res, pivots, sign
+}
+
+func (m *Matrix) Rank() int {
+	_, pivots, _ := m.RREF()
+	return len(pivots)
+}
+
+// Determinant by forward elimination, the matrix must be square
+func (m *Matrix) Det() *big.Rat {
+	if m.rows != m.cols {
+		panic(fmt.Sprintf("Determinant is only defined for square matrices, found %dx%d", m.rows, m.cols))
+	}
+
+	res := m.Clone()
+	det := big.NewRat(1, 1)
+	tmp := new(big.Rat)
+
+	for col := 0; col < res.cols; col++ {
+		pivot := -1
+		for idx := col; idx < res.rows; idx++ {
+			if res.At(idx, col).Sign() != 0 {
+				pivot = idx
+				break
+			}
+		}
+		if pivot < 0 {
+			return new(big.Rat)
+		}
+		if pivot != col {
+			res.swapRows(pivot, col)
+			det.Neg(det)
+		}
+
+		det.Mul(det, res.At(col, col))
+		for idx := col + 1; idx < res.rows; idx++ {
+			if res.At(idx, col).Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Quo(res.At(idx, col), res.At(col, col))
+			for jdx := col; jdx < res.cols; jdx++ {
+				tmp.Mul(factor, res.At(col, jdx))
+				res.At(idx, jdx).Sub(res.At(idx, jdx), tmp)
+			}
+		}
+	}
+
+	return det
+}
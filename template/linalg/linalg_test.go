@@ -0,0 +1,56 @@
+package linalg
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestDetAndRank(t *testing.T) {
+	values := [][]int64{{2, -3, 1}, {2, 0, -1}, {1, 4, 5}}
+
+	det := FromInts(values).Det()
+	if det.Cmp(big.NewRat(49, 1)) != 0 {
+		t.Fatalf("Expected determinant to be 49, it was %s instead", det.RatString())
+	}
+
+	detInt, ok := DetInts(values)
+	if !ok || detInt != 49 {
+		t.Fatalf("Expected integer determinant to be 49, it was %d instead", detInt)
+	}
+
+	rank := FromInts([][]int64{{1, 2, 3}, {2, 4, 6}, {1, 0, 1}}).Rank()
+	if rank != 2 {
+		t.Fatalf("Expected rank to be 2, it was %d instead", rank)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// day 13 example: 94a + 22b = 8400, 34a + 67b = 5400
+	x, ok := SolveInts([][]int64{{94, 22}, {34, 67}}, []int64{8400, 5400})
+	if !ok || !slices.Equal(x, []int64{80, 40}) {
+		t.Fatalf("Expected solution to be [80 40], it was %v instead", x)
+	}
+
+	_, ok = SolveInts([][]int64{{26, 67}, {66, 21}}, []int64{12748, 12176})
+	if ok {
+		t.Fatalf("Expected the system to have no integer solution")
+	}
+
+	// big enough for Cramer's rule to overflow int64
+	big13 := int64(10000000000000)
+	x, ok = SolveInts([][]int64{{94 * big13, 22 * big13}, {34 * big13, 67 * big13}}, []int64{8400 * big13, 5400 * big13})
+	if !ok || !slices.Equal(x, []int64{80, 40}) {
+		t.Fatalf("Expected solution to be [80 40] after falling back to big numbers, it was %v instead", x)
+	}
+
+	_, kind := Solve(FromInts([][]int64{{1, 1}, {2, 2}}), []*big.Rat{big.NewRat(1, 1), big.NewRat(3, 1)})
+	if kind != NO_SOLUTION {
+		t.Fatalf("Expected inconsistent system to have no solution")
+	}
+
+	sol, kind := Solve(FromInts([][]int64{{1, 1}, {2, 2}}), []*big.Rat{big.NewRat(1, 1), big.NewRat(2, 1)})
+	if kind != INFINITE_SOLUTIONS || sol[0].Cmp(big.NewRat(1, 1)) != 0 {
+		t.Fatalf("Expected infinitely many solutions, found %v", sol)
+	}
+}
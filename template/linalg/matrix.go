@@ -0,0 +1,105 @@
+package linalg
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Dense matrix of exact rationals, stored line by line
+type Matrix struct {
+	rows int
+	cols int
+	data []*big.Rat
+}
+
+func NewMatrix(rows int, cols int) *Matrix {
+	data := make([]*big.Rat, rows*cols)
+	for idx := range data {
+		data[idx] = new(big.Rat)
+	}
+	return &Matrix{rows, cols, data}
+}
+
+func FromInts(values [][]int64) *Matrix {
+	if len(values) == 0 {
+		return NewMatrix(0, 0)
+	}
+
+	m := NewMatrix(len(values), len(values[0]))
+	for idx, line := range values {
+		if len(line) != m.cols {
+			panic(fmt.Sprintf("Line %d has %d columns, expected %d", idx, len(line), m.cols))
+		}
+		for jdx, v := range line {
+			m.At(idx, jdx).SetInt64(v)
+		}
+	}
+	return m
+}
+
+func (m *Matrix) Rows() int {
+	return m.rows
+}
+
+func (m *Matrix) Cols() int {
+	return m.cols
+}
+
+// The returned value is the one stored in the matrix, changing it changes the matrix
+func (m *Matrix) At(i int, j int) *big.Rat {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("Position (%d, %d) is outside of a %dx%d matrix", i, j, m.rows, m.cols))
+	}
+	return m.data[i*m.cols+j]
+}
+
+func (m *Matrix) Set(i int, j int, v *big.Rat) {
+	m.At(i, j).Set(v)
+}
+
+func (m *Matrix) Clone() *Matrix {
+	res := NewMatrix(m.rows, m.cols)
+	for idx, v := range m.data {
+		res.data[idx].Set(v)
+	}
+	return res
+}
+
+// Appends the columns of other to the right of m, used to build [A | b]
+func (m *Matrix) Augment(other *Matrix) *Matrix {
+	if m.rows != other.rows {
+		panic(fmt.Sprintf("Can't augment a matrix with %d lines with one with %d lines", m.rows, other.rows))
+	}
+
+	res := NewMatrix(m.rows, m.cols+other.cols)
+	for idx := 0; idx < m.rows; idx++ {
+		for jdx := 0; jdx < m.cols; jdx++ {
+			res.Set(idx, jdx, m.At(idx, jdx))
+		}
+		for jdx := 0; jdx < other.cols; jdx++ {
+			res.Set(idx, m.cols+jdx, other.At(idx, jdx))
+		}
+	}
+	return res
+}
+
+func (m *Matrix) swapRows(i int, j int) {
+	for k := 0; k < m.cols; k++ {
+		m.data[i*m.cols+k], m.data[j*m.cols+k] = m.data[j*m.cols+k], m.data[i*m.cols+k]
+	}
+}
+
+func (m *Matrix) String() string {
+	var sb strings.Builder
+	for idx := 0; idx < m.rows; idx++ {
+		for jdx := 0; jdx < m.cols; jdx++ {
+			if jdx > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(m.At(idx, jdx).RatString())
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package linalg
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type SolutionKind int
+
+const (
+	NO_SOLUTION SolutionKind = iota
+	UNIQUE_SOLUTION
+	INFINITE_SOLUTIONS
+)
+
+// Solves a * x = b exactly. For INFINITE_SOLUTIONS the returned x is the particular
+// solution with all the free variables set to 0, for NO_SOLUTION it is nil.
+func Solve(a *Matrix, b []*big.Rat) ([]*big.Rat, SolutionKind) {
+	if len(b) != a.rows {
+		panic(fmt.Sprintf("Expected %d values on the right hand side, found %d", a.rows, len(b)))
+	}
+
+	rhs := NewMatrix(a.rows, 1)
+	for idx, v := range b {
+		rhs.Set(idx, 0, v)
+	}
+
+	reduced, pivots, _ := a.Augment(rhs).RREF()
+
+	// a pivot on the augmented column means 0 = 1
+	if len(pivots) > 0 && pivots[len(pivots)-1] == a.cols {
+		return nil, NO_SOLUTION
+	}
+
+	x := make([]*big.Rat, a.cols)
+	for idx := range x {
+		x[idx] = new(big.Rat)
+	}
+	for line, col := range pivots {
+		x[col].Set(reduced.At(line, a.cols))
+	}
+
+	if len(pivots) < a.cols {
+		return x, INFINITE_SOLUTIONS
+	}
+	return x, UNIQUE_SOLUTION
+}
+
+// Solves a square integer system that has a unique solution, and reports whether that solution is
+// made only of integers. Uses Cramer's rule over int64 while nothing overflows and falls back to
+// exact big rationals otherwise.
+func SolveInts(a [][]int64, b []int64) ([]int64, bool) {
+	if x, ok, overflow := solveIntsCramer(a, b); !overflow {
+		return x, ok
+	}
+
+	rhs := make([]*big.Rat, len(b))
+	for idx, v := range b {
+		rhs[idx] = new(big.Rat).SetInt64(v)
+	}
+
+	sol, kind := Solve(FromInts(a), rhs)
+	if kind != UNIQUE_SOLUTION {
+		return nil, false
+	}
+
+	res := make([]int64, len(sol))
+	for idx, v := range sol {
+		if !v.IsInt() || !v.Num().IsInt64() {
+			return nil, false
+		}
+		res[idx] = v.Num().Int64()
+	}
+	return res, true
+}
+
+func solveIntsCramer(a [][]int64, b []int64) ([]int64, bool, bool) {
+	det, ok := DetInts(a)
+	if !ok {
+		return nil, false, true
+	}
+	if det == 0 {
+		return nil, false, false
+	}
+
+	res := make([]int64, len(a))
+	for col := range a {
+		replaced := make([][]int64, len(a))
+		for idx := range a {
+			replaced[idx] = append([]int64{}, a[idx]...)
+			replaced[idx][col] = b[idx]
+		}
+
+		detCol, ok := DetInts(replaced)
+		if !ok {
+			return nil, false, true
+		}
+		if detCol%det != 0 {
+			return nil, false, false
+		}
+		res[col] = detCol / det
+	}
+	return res, true, false
+}
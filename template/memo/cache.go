@@ -0,0 +1,87 @@
+package memo
+
+import "container/list"
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Put(key K, value V)
+	Len() int
+	Clear()
+}
+
+// Unbounded cache, never evicts anything
+type MapCache[K comparable, V any] map[K]V
+
+func NewMapCache[K comparable, V any]() MapCache[K, V] {
+	return MapCache[K, V]{}
+}
+
+func (c MapCache[K, V]) Get(key K) (V, bool) {
+	v, ok := c[key]
+	return v, ok
+}
+
+func (c MapCache[K, V]) Put(key K, value V) {
+	c[key] = value
+}
+
+func (c MapCache[K, V]) Len() int {
+	return len(c)
+}
+
+func (c MapCache[K, V]) Clear() {
+	clear(c)
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Keeps at most capacity entries, evicting the least recently used one first
+type LRUCache[K comparable, V any] struct {
+	capacity int
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	if capacity <= 0 {
+		panic("LRU cache capacity must be positive")
+	}
+	return &LRUCache[K, V]{capacity, list.New(), map[K]*list.Element{}}
+}
+
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(lruEntry[K, V]).value, true
+}
+
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = lruEntry[K, V]{key, value}
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() == c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(lruEntry[K, V]).key)
+	}
+	c.entries[key] = c.order.PushFront(lruEntry[K, V]{key, value})
+}
+
+func (c *LRUCache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+func (c *LRUCache[K, V]) Clear() {
+	c.order.Init()
+	clear(c.entries)
+}
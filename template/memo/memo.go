@@ -0,0 +1,84 @@
+package memo
+
+import "fmt"
+
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+func (s Stats) String() string {
+	total := s.Hits + s.Misses
+	rate := 0.0
+	if total > 0 {
+		rate = 100 * float64(s.Hits) / float64(total)
+	}
+	return fmt.Sprintf("hits=%d misses=%d hit rate=%.2f%%", s.Hits, s.Misses, rate)
+}
+
+// Memoized function of a single comparable key. Functions of several arguments
+// use a struct as the key:
+//
+//	type Args struct {
+//		seq   string
+//		depth int
+//	}
+//
+// Not safe for concurrent use.
+type Memo[K comparable, V any] struct {
+	f      func(self func(K) V, key K) V
+	cache  Cache[K, V]
+	scoped bool
+	depth  int
+	Stats  Stats
+}
+
+func Memoize[K comparable, V any](f func(K) V, cache Cache[K, V]) *Memo[K, V] {
+	return MemoizeRec(func(_ func(K) V, key K) V { return f(key) }, cache)
+}
+
+// For recursive functions, f gets the memoized version of itself as self and
+// must use it for the recursive calls for them to hit the cache.
+func MemoizeRec[K comparable, V any](f func(self func(K) V, key K) V, cache Cache[K, V]) *Memo[K, V] {
+	return &Memo[K, V]{f: f, cache: cache}
+}
+
+// Clears the cache once every outermost call returns, so entries only live for the duration
+// of one top level computation. Useful when f depends on state that changes between calls.
+func (m *Memo[K, V]) Scoped() *Memo[K, V] {
+	m.scoped = true
+	return m
+}
+
+func (m *Memo[K, V]) Call(key K) V {
+	if v, ok := m.cache.Get(key); ok {
+		m.Stats.Hits++
+		return v
+	}
+	m.Stats.Misses++
+
+	// deferred so that a panic in f doesn't leave the memo thinking it is still inside a call
+	m.depth++
+	defer m.leave()
+
+	v := m.f(m.Call, key)
+	m.cache.Put(key, v)
+	return v
+}
+
+func (m *Memo[K, V]) leave() {
+	m.depth--
+	if m.scoped && m.depth == 0 {
+		m.cache.Clear()
+	}
+}
+
+// Same as Call, handy to pass the memoized function around
+func (m *Memo[K, V]) Func() func(K) V {
+	return m.Call
+}
+
+func (m *Memo[K, V]) Reset() {
+	m.cache.Clear()
+	m.Stats = Stats{}
+}
@@ -0,0 +1,74 @@
+package memo
+
+import (
+	"testing"
+)
+
+func fib(self func(int) uint64, n int) uint64 {
+	if n < 2 {
+		return uint64(n)
+	}
+	return self(n-1) + self(n-2)
+}
+
+func TestMemoizeRec(t *testing.T) {
+	m := MemoizeRec(fib, NewMapCache[int, uint64]())
+
+	if r := m.Call(90); r != 2880067194370816120 {
+		t.Fatalf("Expected fib(90) to be 2880067194370816120, it was %d instead", r)
+	}
+
+	if m.Stats.Misses != 91 || m.Stats.Hits != 88 {
+		t.Fatalf("Expected 91 misses and 88 hits, found %s instead", m.Stats)
+	}
+
+	m.Call(90)
+	if m.Stats.Hits != 89 {
+		t.Fatalf("Expected a second call to be a hit, found %s instead", m.Stats)
+	}
+}
+
+func TestScoped(t *testing.T) {
+	m := MemoizeRec(fib, NewMapCache[int, uint64]()).Scoped()
+	m.Call(30)
+	m.Call(30)
+
+	if m.Stats.Misses != 62 {
+		t.Fatalf("Expected both top level calls to miss, found %s instead", m.Stats)
+	}
+}
+
+func TestScopedAfterPanic(t *testing.T) {
+	cache := NewMapCache[int, uint64]()
+	m := MemoizeRec(func(self func(int) uint64, n int) uint64 {
+		if n == 0 {
+			panic("n is 0")
+		}
+		return fib(self, n)
+	}, cache).Scoped()
+
+	func() {
+		defer func() { recover() }()
+		m.Call(10)
+	}()
+
+	if r := m.Call(1); r != 1 || cache.Len() != 0 {
+		t.Fatalf("Expected the cache to be cleared after a recovered panic, found %d entries instead", cache.Len())
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Put("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Expected b to be evicted")
+	}
+
+	if v, ok := c.Get("a"); !ok || v != 1 || c.Len() != 2 {
+		t.Fatalf("Expected a to still be cached")
+	}
+}
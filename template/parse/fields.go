@@ -0,0 +1,70 @@
+package parse
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Fills the integer fields of the struct pointed to by dst with the integers found in line.
+// Fields are filled in declaration order, a `parse:"N"` tag takes the N-th integer instead
+// and `parse:"-"` skips the field. Unexported fields are skipped as well.
+//
+//	type Robot struct {
+//		X, Y   int
+//		VX, VY int
+//	}
+//	Fields("p=0,4 v=3,-3", &robot)
+func Fields(line string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Expected a pointer to a struct, found %T", dst)
+	}
+
+	ints := Ints(line)
+	s := v.Elem()
+	next := 0
+
+	for idx := 0; idx < s.NumField(); idx++ {
+		field := s.Type().Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		pos := next
+		if tag, ok := field.Tag.Lookup("parse"); ok {
+			if tag == "-" {
+				continue
+			}
+			n, err := strconv.Atoi(tag)
+			if err != nil || n < 0 {
+				return fmt.Errorf("Field %s has invalid parse tag %q", field.Name, tag)
+			}
+			pos = n
+		}
+
+		if pos >= len(ints) {
+			return fmt.Errorf("Field %s needs integer #%d but %q only has %d", field.Name, pos, line, len(ints))
+		}
+
+		fieldValue := s.Field(idx)
+		switch fieldValue.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if fieldValue.OverflowInt(int64(ints[pos])) {
+				return fmt.Errorf("Value %d does not fit in field %s", ints[pos], field.Name)
+			}
+			fieldValue.SetInt(int64(ints[pos]))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if ints[pos] < 0 || fieldValue.OverflowUint(uint64(ints[pos])) {
+				return fmt.Errorf("Value %d does not fit in field %s", ints[pos], field.Name)
+			}
+			fieldValue.SetUint(uint64(ints[pos]))
+		default:
+			return fmt.Errorf("Field %s has unsupported kind %s", field.Name, fieldValue.Kind())
+		}
+
+		next = pos + 1
+	}
+
+	return nil
+}
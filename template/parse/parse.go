@@ -0,0 +1,81 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var INT_REGEX = regexp.MustCompile(`-?\d+`)
+
+// All the signed integers in the line, in order: "p=0,4 v=3,-3" -> [0 4 3 -3].
+// A - right before a digit is always read as a sign, so "1-2" gives [1 -2].
+// Numbers too big for an int (long ids, hashes) are skipped.
+func Ints(line string) []int {
+	res := []int{}
+	for _, match := range INT_REGEX.FindAllString(line, -1) {
+		if n, err := strconv.Atoi(match); err == nil {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// Splits the input into blocks separated by one or more blank lines
+func Sections(lines []string) [][]string {
+	res := [][]string{}
+	curr := []string{}
+
+	for _, line := range lines {
+		if len(line) == 0 {
+			if len(curr) > 0 {
+				res = append(res, curr)
+				curr = []string{}
+			}
+			continue
+		}
+		curr = append(curr, line)
+	}
+
+	if len(curr) > 0 {
+		res = append(res, curr)
+	}
+	return res
+}
+
+// Like strconv.Atoi but panics, lineIdx is only used for the error message (pass -1 if unknown)
+func MustInt(s string, lineIdx int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		if lineIdx < 0 {
+			panic(fmt.Sprintf("Can't parse %q as an integer", s))
+		}
+		panic(fmt.Sprintf("Can't parse %q as an integer on line %d", s, lineIdx+1))
+	}
+	return n
+}
+
+func Chars(lines []string) [][]rune {
+	res := [][]rune{}
+	for _, line := range lines {
+		res = append(res, []rune(line))
+	}
+	return res
+}
+
+// Grid of single digit numbers, anything that's not a digit becomes -1
+func Digits(lines []string) [][]int {
+	res := [][]int{}
+	for _, line := range lines {
+		row := make([]int, 0, len(line))
+		for _, chr := range line {
+			if chr >= '0' && chr <= '9' {
+				row = append(row, int(chr-'0'))
+			} else {
+				row = append(row, -1)
+			}
+		}
+		res = append(res, row)
+	}
+	return res
+}
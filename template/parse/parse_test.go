@@ -0,0 +1,54 @@
+package parse
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	r := Ints("p=0,4 v=3,-3")
+	if !slices.Equal(r, []int{0, 4, 3, -3}) {
+		t.Fatalf("Expected [0 4 3 -3], found %v instead", r)
+	}
+
+	r = Ints("3   4")
+	if !slices.Equal(r, []int{3, 4}) {
+		t.Fatalf("Expected [3 4], found %v instead", r)
+	}
+
+	r = Ints("id 123456789012345678901234567890: 5")
+	if !slices.Equal(r, []int{5}) {
+		t.Fatalf("Expected the id to be skipped and [5] to be found, found %v instead", r)
+	}
+}
+
+func TestSections(t *testing.T) {
+	r := Sections([]string{"47|53", "97|13", "", "", "75,47,61", ""})
+	if len(r) != 2 || len(r[0]) != 2 || r[1][0] != "75,47,61" {
+		t.Fatalf("Expected 2 sections of 2 and 1 lines, found %v instead", r)
+	}
+}
+
+func TestFields(t *testing.T) {
+	var button struct {
+		X uint64
+		Y int `parse:"1"`
+		Z int `parse:"-"`
+	}
+
+	err := Fields("Button A: X+94, Y+34", &button)
+	if err != nil || button.X != 94 || button.Y != 34 {
+		t.Fatalf("Expected X+94 and Y+34, found %+v (%v) instead", button, err)
+	}
+
+	if err = Fields("X+94", &button); err == nil {
+		t.Fatalf("Expected an error when there are not enough integers")
+	}
+
+	var negative struct {
+		X int `parse:"-1"`
+	}
+	if err = Fields("X+94", &negative); err == nil {
+		t.Fatalf("Expected an error for a negative parse tag")
+	}
+}
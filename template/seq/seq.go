@@ -0,0 +1,60 @@
+package seq
+
+import (
+	"aoc-2024/combinatorics"
+	"iter"
+)
+
+// Every unordered pair xs[i], xs[j] with i < j
+func Pairs[T any](xs []T) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		for idx := 0; idx < len(xs); idx++ {
+			for jdx := idx + 1; jdx < len(xs); jdx++ {
+				if !yield(xs[idx], xs[jdx]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Every contiguous window of n elements, left to right. The windows share
+// memory with xs, clone them before modifying or keeping them around.
+func Windows[T any](xs []T, n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n <= 0 {
+			return
+		}
+		for idx := 0; idx+n <= len(xs); idx++ {
+			if !yield(xs[idx : idx+n : idx+n]) {
+				return
+			}
+		}
+	}
+}
+
+// Every k element subset of xs, keeping the order of xs inside each one.
+// Each yielded slice is freshly allocated.
+func Combinations[T any](xs []T, k int) iter.Seq[[]T] {
+	return pickAll(xs, combinatorics.Combinations(len(xs), k))
+}
+
+// Every ordering of xs, in lexicographic order of the positions.
+// Each yielded slice is freshly allocated.
+func Permutations[T any](xs []T) iter.Seq[[]T] {
+	return pickAll(xs, combinatorics.Permutations(len(xs)))
+}
+
+func pickAll[T any](xs []T, indices iter.Seq[[]int]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for picked := range indices {
+			res := make([]T, len(picked))
+			for idx, pos := range picked {
+				res[idx] = xs[pos]
+			}
+			if !yield(res) {
+				return
+			}
+		}
+	}
+}
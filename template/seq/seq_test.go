@@ -0,0 +1,49 @@
+package seq
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPairsAndWindows(t *testing.T) {
+	count := 0
+	for a, b := range Pairs([]int{1, 2, 3, 4}) {
+		if a >= b {
+			t.Fatalf("Expected pairs to be ordered, found (%d, %d)", a, b)
+		}
+		count++
+	}
+	if count != 6 {
+		t.Fatalf("Expected 6 pairs, found %d instead", count)
+	}
+
+	windows := [][]int{}
+	for w := range Windows([]int{-2, 1, -3, 2, 0}, 4) {
+		windows = append(windows, w)
+	}
+	if len(windows) != 2 || !slices.Equal(windows[1], []int{1, -3, 2, 0}) {
+		t.Fatalf("Expected 2 windows ending in [1 -3 2 0], found %v instead", windows)
+	}
+}
+
+func TestCombinationsAndPermutations(t *testing.T) {
+	combinations := [][]string{}
+	for c := range Combinations([]string{"a", "b", "c", "d"}, 3) {
+		combinations = append(combinations, c)
+	}
+	if len(combinations) != 4 || !slices.Equal(combinations[3], []string{"b", "c", "d"}) {
+		t.Fatalf("Expected 4 combinations ending in [b c d], found %v instead", combinations)
+	}
+
+	permutations := [][]int{}
+	for p := range Permutations([]int{1, 2, 3}) {
+		permutations = append(permutations, p)
+	}
+	if len(permutations) != 6 || !slices.Equal(permutations[5], []int{3, 2, 1}) {
+		t.Fatalf("Expected 6 permutations ending in [3 2 1], found %v instead", permutations)
+	}
+
+	for range Combinations([]int{1}, 2) {
+		t.Fatalf("Expected no combinations of 2 out of 1")
+	}
+}